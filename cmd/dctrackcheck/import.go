@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Jethzabell/go-dctrack-client/dctrack"
+)
+
+// handleImport creates or updates the items listed in a CSV or JSON file
+func handleImport(ctx context.Context, client *dctrack.Client, path string, dryRun bool) {
+	fmt.Printf("Importing DCTrack items from: %s\n", path)
+	if dryRun {
+		fmt.Println("Dry run: no changes will be written")
+	}
+	fmt.Println("=====================================")
+
+	inputs, err := readImportFile(path)
+	if err != nil {
+		log.Fatalf("Import failed: %v", err)
+	}
+
+	report, err := client.BulkUpsertItems(ctx, inputs, dctrack.BulkOptions{
+		DryRun: dryRun,
+		Progress: func(done, total int) {
+			fmt.Printf("Processed %d/%d rows\n", done, total)
+		},
+	})
+	if err != nil {
+		log.Fatalf("Import failed: %v", err)
+	}
+
+	printImportReport(report)
+	if report.Failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// readImportFile reads rows keyed by DCTrack field name. Files ending in
+// .json hold an array of objects; anything else is read as CSV with a
+// header row.
+func readImportFile(path string) ([]dctrack.ItemInput, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening import file: %w", err)
+	}
+	defer file.Close()
+
+	var rows []map[string]interface{}
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		if err := json.NewDecoder(file).Decode(&rows); err != nil {
+			return nil, fmt.Errorf("error parsing JSON import file: %w", err)
+		}
+	} else {
+		rows, err = readCSVRows(file)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	inputs := make([]dctrack.ItemInput, len(rows))
+	for i, row := range rows {
+		inputs[i], err = dctrack.ItemInputFromFields(row)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", i+1, err)
+		}
+	}
+	return inputs, nil
+}
+
+// readCSVRows reads a CSV file whose header row names the DCTrack fields
+func readCSVRows(r io.Reader) ([]map[string]interface{}, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("error reading CSV header: %w", err)
+	}
+	// Spreadsheet exports often start with a byte order mark
+	for i := range header {
+		header[i] = strings.TrimSpace(strings.TrimPrefix(header[i], "\ufeff"))
+	}
+
+	var rows []map[string]interface{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading CSV: %w", err)
+		}
+
+		row := make(map[string]interface{}, len(header))
+		for i, value := range record {
+			if i < len(header) && header[i] != "" {
+				row[header[i]] = value
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func printImportReport(report *dctrack.BulkReport) {
+	fmt.Println()
+	for _, row := range report.Rows {
+		// Row numbers match the file, counting the first data row as 1
+		line := fmt.Sprintf("Row %d: %s", row.Row+1, row.Action)
+		if row.ID != "" {
+			line += fmt.Sprintf(" (item %s)", row.ID)
+		}
+		if row.Reason != "" {
+			line += ": " + row.Reason
+		}
+		if row.Err != nil {
+			line += fmt.Sprintf(": %v", row.Err)
+		}
+		fmt.Println(line)
+
+		if row.Action == dctrack.BulkUpdated || (report.DryRun && row.Action == dctrack.BulkCreated) {
+			for _, change := range row.Changes {
+				fmt.Printf("    %s: %q -> %q\n", change.Field, change.Old, change.New)
+			}
+		}
+	}
+
+	fmt.Println()
+	fmt.Printf("Created: %d  Updated: %d  Skipped: %d  Failed: %d\n",
+		report.Created, report.Updated, report.Skipped, report.Failed)
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadImportFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		content  string
+		expected []string // Serial numbers per row
+	}{
+		{
+			name: "csv with byte order mark",
+			file: "devices.csv",
+			content: "\ufefftiName,tiSerialNumber,cmbCabinet,tiCustomField_Asset Status\n" +
+				"web-01,SN-1,CAB-A1,Active\n" +
+				"web-02,SN-2,,\n",
+			expected: []string{"SN-1", "SN-2"},
+		},
+		{
+			name:     "json array",
+			file:     "devices.json",
+			content:  `[{"tiName":"web-01","tiSerialNumber":"SN-1","cmbCabinet":"CAB-A1","tiCustomField_Asset Status":"Active"}]`,
+			expected: []string{"SN-1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			if err := os.WriteFile(path, []byte(tt.content), 0600); err != nil {
+				t.Fatalf("Failed to write import file: %v", err)
+			}
+
+			inputs, err := readImportFile(path)
+			if err != nil {
+				t.Fatalf("readImportFile failed: %v", err)
+			}
+			if len(inputs) != len(tt.expected) {
+				t.Fatalf("Expected %d rows, got %d", len(tt.expected), len(inputs))
+			}
+			for i, serial := range tt.expected {
+				if inputs[i].SerialNumber == nil || *inputs[i].SerialNumber != serial {
+					t.Errorf("Row %d: expected serial %s, got %v", i, serial, inputs[i].SerialNumber)
+				}
+			}
+
+			first := inputs[0]
+			if first.Name == nil || *first.Name != "web-01" {
+				t.Errorf("Expected name from header column tiName, got %v", first.Name)
+			}
+			if first.Cabinet == nil || *first.Cabinet != "CAB-A1" {
+				t.Errorf("Expected cabinet CAB-A1, got %v", first.Cabinet)
+			}
+			if first.CustomFields["Asset Status"] != "Active" {
+				t.Errorf("Expected custom field in CustomFields, got %v", first.CustomFields)
+			}
+			if len(inputs) > 1 && inputs[1].Cabinet != nil {
+				t.Errorf("Expected blank cell to leave cabinet unset")
+			}
+		})
+	}
+}
+
+func TestReadImportFileErrors(t *testing.T) {
+	dir := t.TempDir()
+	badDate := filepath.Join(dir, "bad.csv")
+	os.WriteFile(badDate, []byte("tiName,purchaseDate\nweb-01,yesterday\n"), 0600)
+
+	if _, err := readImportFile(badDate); err == nil {
+		t.Errorf("Expected error for invalid date")
+	}
+	if _, err := readImportFile(filepath.Join(dir, "missing.csv")); err == nil {
+		t.Errorf("Expected error for missing file")
+	}
+}
@@ -2,30 +2,41 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
-	dctrack "github.com/Jethzabell/go-dctrack-client"
+	"github.com/Jethzabell/go-dctrack-client/dctrack"
+	"github.com/Jethzabell/go-dctrack-client/dctrack/analytics"
 	"go.uber.org/zap"
 )
 
 const (
 	defaultPageSize = 100
 	defaultTimeout  = 30 * time.Second
+	importTimeout   = 30 * time.Minute
 )
 
 func main() {
-	if len(os.Args) < 2 {
+	configPath := flag.String("config", os.Getenv("DCTRACK_CONFIG"), "Multi-environment config file (YAML or JSON)")
+	profile := flag.String("profile", "", "Profile to load from the config file (default: $DCTRACK_PROFILE)")
+	dryRun := flag.Bool("dry-run", false, "Show what import would change without writing")
+	flag.Usage = printUsage
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) < 1 {
 		printUsage()
 		os.Exit(1)
 	}
 
-	// Get configuration from environment variables
-	config := getConfigFromEnv()
-	if err := validateConfig(config); err != nil {
+	// Get configuration from the config file or environment variables
+	config, err := loadConfig(*configPath, *profile)
+	if err != nil {
 		log.Fatalf("Configuration error: %v", err)
 	}
 
@@ -37,22 +48,25 @@ func main() {
 	defer logger.Sync()
 
 	// Create DCTrack client
-	client, err := dctrack.NewClient(config)
-	if err != nil {
-		log.Fatalf("Failed to create DCTrack client: %v", err)
-	}
-	client.SetLogger(logger)
+	client := dctrack.NewClient(config, logger)
 	defer client.Close()
 
 	// Parse command line arguments
-	searchQuery := os.Args[1]
+	searchQuery := args[0]
 	command := "search"
-	if len(os.Args) > 2 {
-		command = os.Args[1]
-		searchQuery = os.Args[2]
+	if len(args) > 1 {
+		command = args[0]
+		searchQuery = args[1]
+	} else if args[0] == "locations" {
+		command = "locations" // The location argument is optional
+		searchQuery = ""
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	timeout := defaultTimeout
+	if command == "import" {
+		timeout = importTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
 	switch command {
@@ -64,16 +78,54 @@ func main() {
 		handleGetItem(ctx, client, searchQuery)
 	case "power":
 		handlePowerAnalysis(ctx, client, searchQuery)
+	case "powerchain":
+		handlePowerChain(ctx, client, searchQuery)
+	case "import":
+		handleImport(ctx, client, searchQuery, *dryRun)
+	case "locations":
+		handleLocations(ctx, client, searchQuery)
+	case "ports":
+		handlePorts(ctx, client, searchQuery)
+	case "where":
+		handleWhere(ctx, client, searchQuery)
+	case "summary":
+		handleSummary(ctx, client, searchQuery)
+	case "refresh":
+		handleRefresh(ctx, client, searchQuery)
+	case "audit":
+		handleAudit(ctx, client, searchQuery)
+	case "vendors":
+		itemClass := ""
+		if len(args) > 2 {
+			itemClass = args[2]
+		}
+		handleVendors(ctx, client, searchQuery, itemClass)
+	case "capacity":
+		handleCapacity(ctx, client, searchQuery)
+	case "fit":
+		handleFit(ctx, client, searchQuery, args[2:])
 	default:
 		handleSearch(ctx, client, command) // Treat as search query
 	}
 }
 
+// loadConfig reads the selected profile when a config file is given and
+// falls back to environment variables otherwise
+func loadConfig(configPath, profile string) (dctrack.Config, error) {
+	if configPath == "" {
+		config := getConfigFromEnv()
+		return config, validateConfig(config)
+	}
+
+	return dctrack.LoadConfig(configPath, profile)
+}
+
 func getConfigFromEnv() dctrack.Config {
 	config := dctrack.Config{
 		URL:              getEnvOrDefault("DCTRACK_URL", ""),
 		Username:         getEnvOrDefault("DCTRACK_USERNAME", ""),
 		Password:         getEnvOrDefault("DCTRACK_PASSWORD", ""),
+		PasswordFile:     getEnvOrDefault("DCTRACK_PASSWORD_FILE", ""),
 		PageSize:         getEnvIntOrDefault("DCTRACK_PAGE_SIZE", defaultPageSize),
 		MaxRetries:       getEnvIntOrDefault("DCTRACK_MAX_RETRIES", 3),
 		RetryDelay:       time.Duration(getEnvIntOrDefault("DCTRACK_RETRY_DELAY", 1)) * time.Second,
@@ -91,7 +143,7 @@ func validateConfig(config dctrack.Config) error {
 	if config.Username == "" {
 		return fmt.Errorf("DCTRACK_USERNAME environment variable is required")
 	}
-	if config.Password == "" {
+	if config.Password == "" && config.PasswordFile == "" && config.CredentialProvider == nil {
 		return fmt.Errorf("DCTRACK_PASSWORD environment variable is required")
 	}
 	return nil
@@ -121,6 +173,40 @@ func handleSearch(ctx context.Context, client *dctrack.Client, query string) {
 	}
 }
 
+func handleWhere(ctx context.Context, client *dctrack.Client, expression string) {
+	filter, err := dctrack.ParseFilter(expression)
+	if err != nil {
+		log.Fatalf("Invalid filter: %v", err)
+	}
+	plan, err := dctrack.CompileFilter(filter)
+	if err != nil {
+		log.Fatalf("Invalid filter: %v", err)
+	}
+
+	fmt.Printf("Filtering DCTrack items: %s\n", filter)
+	fmt.Printf("Plan: %s\n", plan)
+	fmt.Println("=====================================")
+
+	items, err := client.GetItemsWhere(ctx, dctrack.ItemsParams{PageSize: defaultPageSize}, filter)
+	if err != nil {
+		log.Fatalf("Filter failed: %v", err)
+	}
+
+	if len(items) == 0 {
+		fmt.Println("No items found")
+		return
+	}
+
+	fmt.Printf("Found %d items:\n\n", len(items))
+	for i, item := range items {
+		if i >= 10 { // Limit output
+			fmt.Printf("... and %d more items\n", len(items)-10)
+			break
+		}
+		printItemSummary(item)
+	}
+}
+
 func handleList(ctx context.Context, client *dctrack.Client, location string) {
 	fmt.Printf("Listing DCTrack items in location: %s\n", location)
 	fmt.Println("==========================================")
@@ -140,20 +226,20 @@ func handleList(ctx context.Context, client *dctrack.Client, location string) {
 
 	fmt.Printf("Found %d items in %s:\n\n", len(items), location)
 
-	// Group by vendor
-	vendorCounts := make(map[string]int)
 	totalPower := 0.0
-
 	for _, item := range items {
-		if item.Make != "" {
-			vendorCounts[item.Make]++
-		}
 		totalPower += item.OriginalPower
 	}
 
+	vendors, err := analytics.VendorMix(items, analytics.VendorOptions{})
+	if err != nil {
+		log.Fatalf("Vendor distribution failed: %v", err)
+	}
 	fmt.Println("Vendor Distribution:")
-	for vendor, count := range vendorCounts {
-		fmt.Printf("  %s: %d assets\n", vendor, count)
+	for _, share := range vendors.Makes {
+		if share.Make != "" {
+			fmt.Printf("  %s: %d assets (%.1f%%)\n", share.Make, share.AssetCount, share.Percentage)
+		}
 	}
 
 	fmt.Printf("\nTotal Power: %.2f kW\n", totalPower/1000)
@@ -176,50 +262,332 @@ func handlePowerAnalysis(ctx context.Context, client *dctrack.Client, location s
 	fmt.Printf("Power analysis for location: %s\n", location)
 	fmt.Println("==================================")
 
-	params := dctrack.ByLocation(location)
-	items, err := client.GetItemsWithParams(ctx, params)
+	assets, err := client.GetItemsWithParams(ctx, dctrack.ItemsParams{Location: location, Columns: []string{"tiClass"}})
 	if err != nil {
 		log.Fatalf("Power analysis failed: %v", err)
 	}
-
-	if len(items) == 0 {
+	if len(assets) == 0 {
 		fmt.Printf("No items found in location: %s\n", location)
 		return
 	}
 
+	items, err := client.GetPowerItems(ctx, dctrack.PowerParams{Location: location})
+	if err != nil {
+		log.Fatalf("Power analysis failed: %v", err)
+	}
+
 	// Calculate power statistics
 	totalPower := 0.0
-	powerAssets := 0
 	maxPower := 0.0
-	minPower := float64(^uint(0) >> 1) // Max float64
+	minPower := 0.0
 
-	for _, item := range items {
-		if item.OriginalPower > 0 {
-			totalPower += item.OriginalPower
-			powerAssets++
-			if item.OriginalPower > maxPower {
-				maxPower = item.OriginalPower
-			}
-			if item.OriginalPower < minPower {
-				minPower = item.OriginalPower
-			}
+	for i, item := range items {
+		totalPower += item.OriginalPower
+		maxPower = max(maxPower, item.OriginalPower)
+		if i == 0 || item.OriginalPower < minPower {
+			minPower = item.OriginalPower
 		}
 	}
 
 	avgPower := 0.0
-	if powerAssets > 0 {
-		avgPower = totalPower / float64(powerAssets)
+	if len(items) > 0 {
+		avgPower = totalPower / float64(len(items))
 	}
 
-	fmt.Printf("Total Assets: %d\n", len(items))
-	fmt.Printf("Assets with Power Data: %d\n", powerAssets)
+	fmt.Printf("Total Assets: %d\n", len(assets))
+	fmt.Printf("Assets with Power Data: %d\n", len(items))
 	fmt.Printf("Total Power: %.2f kW\n", totalPower/1000)
 	fmt.Printf("Average Power: %.2f W\n", avgPower)
 	fmt.Printf("Maximum Power: %.2f W\n", maxPower)
-	if minPower != float64(^uint(0)>>1) {
+	if len(items) > 0 {
 		fmt.Printf("Minimum Power: %.2f W\n", minPower)
 	}
-	fmt.Printf("Power Density: %.2f W/asset\n", totalPower/float64(len(items)))
+	fmt.Printf("Power Density: %.2f W/asset\n", totalPower/float64(len(assets)))
+}
+
+func handleSummary(ctx context.Context, client *dctrack.Client, groupBy string) {
+	var keys []analytics.GroupKey
+	for _, key := range strings.Split(groupBy, ",") {
+		keys = append(keys, analytics.GroupKey(strings.TrimSpace(key)))
+	}
+
+	fmt.Printf("Installed assets by %s\n", groupBy)
+	fmt.Println("==================================")
+
+	params := dctrack.InstalledOnly()
+	params.PageSize = defaultPageSize
+	summaries, err := analytics.NewClient(client).Summarize(ctx, params, analytics.Options{GroupBy: keys})
+	if err != nil {
+		log.Fatalf("Summary failed: %v", err)
+	}
+
+	if len(summaries) == 0 {
+		fmt.Println("No items found")
+		return
+	}
+
+	for _, summary := range summaries {
+		fmt.Printf("%s: %d assets, %.2f kW, %d RU, %.2f W/RU, purchase value %.0f, weight %.1f\n",
+			summary.Label(), summary.TotalAssets, summary.TotalPowerKW, summary.TotalRUs,
+			summary.PowerDensityPerRU, summary.TotalPurchaseValue, summary.TotalWeight)
+	}
+}
+
+func handleRefresh(ctx context.Context, client *dctrack.Client, location string) {
+	fmt.Printf("Refresh plan for location: %s\n", location)
+	fmt.Println("==================================")
+
+	params := dctrack.InstalledOnly()
+	if location != "all" {
+		params = dctrack.ByLocation(location)
+	}
+	params.PageSize = defaultPageSize
+
+	// Five years for every class, or sooner when the warranty runs out
+	policy := analytics.RefreshPolicy{DefaultMaxAge: 5, RefreshAtWarrantyEnd: true, Years: 5}
+	plan, err := analytics.NewClient(client).PlanRefresh(ctx, params, policy)
+	if err != nil {
+		log.Fatalf("Refresh plan failed: %v", err)
+	}
+
+	fmt.Printf("Assets scheduled: %d (%d without dates)\n\n", plan.TotalAssetsTracked, plan.Unscheduled)
+	for _, refresh := range plan.UpcomingRefreshes {
+		fmt.Printf("%s: %d assets (%d overdue), purchase value %.0f, contracts %.0f, %s\n",
+			refresh.Year, refresh.AssetCount, refresh.OverdueCount, refresh.TotalValue,
+			refresh.ContractValue, strings.Join(refresh.Locations, ", "))
+	}
+}
+
+func handleAudit(ctx context.Context, client *dctrack.Client, location string) {
+	fmt.Printf("Data-quality audit for location: %s\n", location)
+	fmt.Println("=======================================")
+
+	params := dctrack.ItemsParams{}
+	if location != "all" {
+		params.Location = location
+	}
+	params.PageSize = defaultPageSize
+
+	report, err := analytics.NewClient(client).Audit(ctx, params, analytics.AuditOptions{})
+	if err != nil {
+		log.Fatalf("Audit failed: %v", err)
+	}
+
+	fmt.Printf("Assets audited: %d\n\n", report.TotalAssets)
+	for _, result := range report.Rules {
+		fmt.Printf("%-30s %5d", result.Rule, result.Count)
+		if len(result.ItemIDs) > 0 && len(result.ItemIDs) <= 10 {
+			fmt.Printf("  %s", strings.Join(result.ItemIDs, ", "))
+		}
+		fmt.Println()
+	}
+
+	fmt.Println("\nScore by admin team:")
+	for _, score := range report.ByTeam {
+		team := score.Group
+		if team == "" {
+			team = "(none)"
+		}
+		fmt.Printf("  %-28s %5.1f%%  %d of %d assets with issues\n", team, score.Score, score.FailingAssets, score.Assets)
+	}
+}
+
+func handleVendors(ctx context.Context, client *dctrack.Client, location, itemClass string) {
+	fmt.Printf("Vendor mix for location: %s\n", location)
+	fmt.Println("===============================")
+
+	opts := analytics.VendorOptions{ItemClass: itemClass}
+	params := dctrack.InstalledOnly()
+	if location != "all" {
+		opts.Location = location
+	}
+	params.PageSize = defaultPageSize
+
+	report, err := analytics.NewClient(client).VendorMix(ctx, params, opts)
+	if err != nil {
+		log.Fatalf("Vendor mix failed: %v", err)
+	}
+
+	fmt.Printf("Assets: %d, power %.2f kW, purchase value %.0f\n\n", report.TotalAssets, report.TotalPowerKW, report.PurchaseValue)
+	fmt.Printf("%-24s %7s %8s %8s %8s\n", "Make", "Assets", "Assets%", "Power%", "Cost%")
+	for _, share := range report.Makes {
+		name := share.Make
+		if name == "" {
+			name = "(none)"
+		}
+		fmt.Printf("%-24s %7d %7.1f%% %7.1f%% %7.1f%%\n", name, share.AssetCount, share.Percentage,
+			share.PowerPercentage, share.CostPercentage)
+	}
+	if len(report.LongTail) > 0 {
+		fmt.Printf("\nLong tail (under 2%% of assets): %s\n", strings.Join(report.LongTail, ", "))
+	}
+}
+
+func handleCapacity(ctx context.Context, client *dctrack.Client, location string) {
+	fmt.Printf("Cabinet capacity for location: %s\n", location)
+	fmt.Println("=====================================")
+
+	params := dctrack.ItemsParams{Location: location, PageSize: defaultPageSize}
+	capacities, err := analytics.NewClient(client).CabinetCapacities(ctx, params, analytics.CapacityOptions{})
+	if err != nil {
+		log.Fatalf("Capacity failed: %v", err)
+	}
+
+	fmt.Printf("%-16s %9s %6s %12s %14s\n", "Cabinet", "RU used", "Block", "Power left", "Weight left")
+	for _, capacity := range capacities {
+		power, weight := "unknown", "unknown"
+		if capacity.PowerCapacity > 0 {
+			power = fmt.Sprintf("%.0f W", capacity.PowerHeadroom)
+		}
+		if capacity.WeightCapacity > 0 {
+			weight = fmt.Sprintf("%.1f", capacity.WeightHeadroom)
+		}
+		fmt.Printf("%-16s %4d/%-4d %5dU %12s %14s\n", capacity.Cabinet, capacity.UsedRU, capacity.TotalRU,
+			capacity.LargestFreeBlock, power, weight)
+	}
+}
+
+func handleFit(ctx context.Context, client *dctrack.Client, location string, args []string) {
+	if len(args) != 3 {
+		log.Fatalf("Usage: dctrackcheck fit <location> <height-ru> <power-w> <weight>")
+	}
+	height, err := strconv.Atoi(args[0])
+	if err != nil {
+		log.Fatalf("Invalid height %q: %v", args[0], err)
+	}
+	power, err := strconv.ParseFloat(args[1], 64)
+	if err != nil {
+		log.Fatalf("Invalid power %q: %v", args[1], err)
+	}
+	weight, err := strconv.ParseFloat(args[2], 64)
+	if err != nil {
+		log.Fatalf("Invalid weight %q: %v", args[2], err)
+	}
+
+	fmt.Printf("Cabinets in %s fitting %dU, %.0f W, %.1f weight\n", location, height, power, weight)
+	fmt.Println("==================================================")
+
+	req := analytics.PlacementRequest{Height: height, Power: power, Weight: weight}
+	fits, err := analytics.NewClient(client).FindPlacement(ctx, location, req, analytics.CapacityOptions{})
+	if err != nil {
+		log.Fatalf("Placement failed: %v", err)
+	}
+	if len(fits) == 0 {
+		fmt.Println("No cabinet has room")
+		return
+	}
+	for _, fit := range fits {
+		note := ""
+		if fit.PowerUnknown || fit.WeightUnknown {
+			note = " (capacity not fully known)"
+		}
+		fmt.Printf("%-16s U%d-U%d, %.0f W and %.1f weight left%s\n", fit.Cabinet, fit.Range.Bottom, fit.Range.Top,
+			fit.PowerHeadroom, fit.WeightHeadroom, note)
+	}
+}
+
+func handlePowerChain(ctx context.Context, client *dctrack.Client, itemID string) {
+	fmt.Printf("Power chain for DCTrack item: %s\n", itemID)
+	fmt.Println("=================================")
+
+	chains, err := client.GetPowerChains(ctx, itemID)
+	if err != nil {
+		log.Fatalf("Failed to get power chain: %v", err)
+	}
+
+	if len(chains) == 0 {
+		fmt.Println("No power inputs found")
+		return
+	}
+
+	for _, chain := range chains {
+		fmt.Printf("%s:\n", chain.Source.Name)
+		for _, node := range chain.Nodes {
+			label := node.ItemName
+			if node.Port != "" {
+				label += " " + node.Port
+			}
+			fmt.Printf("  %-10s %-24s rated %8.0f W  budget %8.0f W (%5.1f%%)  measured %8.0f W (%5.1f%%)\n",
+				node.Kind, label, node.Load.RatedWatts,
+				node.Load.BudgetedWatts, node.Load.BudgetPercent(),
+				node.Load.MeasuredWatts, node.Load.MeasuredPercent())
+		}
+		if !chain.Complete {
+			fmt.Printf("  PROBLEM: %s\n", chain.Problem)
+		}
+	}
+}
+
+func handleLocations(ctx context.Context, client *dctrack.Client, location string) {
+	fmt.Println("DCTrack location hierarchy")
+	fmt.Println("==========================")
+
+	tree, err := client.GetLocationTree(ctx)
+	if err != nil {
+		log.Fatalf("Failed to get locations: %v", err)
+	}
+
+	roots := tree.Roots
+	if location != "" {
+		roots = tree.FindAll(location)
+		if len(roots) == 0 {
+			fmt.Printf("No location found matching: %s\n", location)
+			return
+		}
+	}
+
+	for _, root := range roots {
+		if root.Parent != nil {
+			fmt.Printf("%s\n", root.PathString())
+		}
+		subtree := dctrack.LocationTree{Roots: []*dctrack.LocationNode{root}}
+		subtree.Walk(func(node *dctrack.LocationNode, depth int) bool {
+			fmt.Printf("%*s%s (%s", depth*2, "", node.Name, node.Level)
+			if node.Code != node.Name {
+				fmt.Printf(", %s", node.Code)
+			}
+			fmt.Printf("): %d items\n", node.TotalItems)
+			return true
+		})
+	}
+}
+
+func handlePorts(ctx context.Context, client *dctrack.Client, itemID string) {
+	fmt.Printf("Data ports of DCTrack item: %s\n", itemID)
+	fmt.Println("=================================")
+
+	ports, err := client.GetDataPorts(ctx, itemID)
+	if err != nil {
+		log.Fatalf("Failed to get ports: %v", err)
+	}
+
+	if len(ports) == 0 {
+		fmt.Println("No data ports found")
+		return
+	}
+
+	problems := 0
+	for _, port := range ports {
+		if !port.Connected() {
+			fmt.Printf("%s: not connected\n", port.Name)
+			continue
+		}
+
+		trace, err := client.TraceDataPort(ctx, itemID, port.ID)
+		if err != nil {
+			log.Fatalf("Failed to trace port %s: %v", port.Name, err)
+		}
+		fmt.Printf("%s: %s\n", port.Name, trace.Path())
+		if !trace.Complete {
+			problems++
+			fmt.Printf("  PROBLEM: %s\n", trace.Problem)
+		}
+	}
+
+	if problems > 0 {
+		fmt.Printf("\n%d ports have cabling problems\n", problems)
+		os.Exit(1)
+	}
 }
 
 func printItemSummary(item dctrack.DCTrackItem) {
@@ -268,16 +636,35 @@ func printUsage() {
 	fmt.Println("dctrackcheck - DCTrack API testing tool")
 	fmt.Println("")
 	fmt.Println("Usage:")
+	fmt.Println("  dctrackcheck [--config file --profile name] <command> <argument>")
+	fmt.Println("")
 	fmt.Println("  dctrackcheck <search-query>                 # Search for items")
 	fmt.Println("  dctrackcheck search <query>                 # Search for items")
 	fmt.Println("  dctrackcheck list <location>                # List items by location")
 	fmt.Println("  dctrackcheck item <item-id>                 # Get specific item details")
 	fmt.Println("  dctrackcheck power <location>               # Power analysis for location")
+	fmt.Println("  dctrackcheck powerchain <item-id>           # Outlet, PDU, breaker and UPS feeding an item")
+	fmt.Println("  dctrackcheck import <file.csv|file.json>    # Create or update items from a file")
+	fmt.Println("  dctrackcheck locations [location]           # Show the location tree with item counts")
+	fmt.Println("  dctrackcheck ports <item-id>                # Trace the cabling of an item's data ports")
+	fmt.Println("  dctrackcheck where <filter>                 # Items matching a filter expression")
+	fmt.Println("  dctrackcheck summary <keys>                 # Totals grouped by location,room,row,cabinet,admin_team,customer")
+	fmt.Println("  dctrackcheck refresh <location|all>         # Assets due for refresh per year over five years")
+	fmt.Println("  dctrackcheck audit <location|all>           # Data-quality rule failures and scores per team")
+	fmt.Println("  dctrackcheck vendors <location|all> [class] # Asset, power and cost share per normalized make")
+	fmt.Println("  dctrackcheck capacity <location>            # RU, power and weight headroom per cabinet")
+	fmt.Println("  dctrackcheck fit <location> <ru> <w> <wt>   # Cabinets a device fits in, best fit first")
+	fmt.Println("")
+	fmt.Println("Flags:")
+	fmt.Println("  --config   Config file with environment profiles (default: $DCTRACK_CONFIG)")
+	fmt.Println("  --profile  Profile to use from the config file (default: $DCTRACK_PROFILE)")
+	fmt.Println("  --dry-run  Show what import would change without writing")
 	fmt.Println("")
-	fmt.Println("Environment Variables:")
+	fmt.Println("Environment Variables (override config file values):")
 	fmt.Println("  DCTRACK_URL        DCTrack API URL (required)")
 	fmt.Println("  DCTRACK_USERNAME   DCTrack username (required)")
-	fmt.Println("  DCTRACK_PASSWORD   DCTrack password (required)")
+	fmt.Println("  DCTRACK_PASSWORD   DCTrack password (required unless DCTRACK_PASSWORD_FILE is set)")
+	fmt.Println("  DCTRACK_PASSWORD_FILE File containing the DCTrack password")
 	fmt.Println("  DCTRACK_PAGE_SIZE  Page size for API requests (default: 100)")
 	fmt.Println("  DCTRACK_VERIFY_SSL Verify SSL certificates (default: true)")
 	fmt.Println("  DCTRACK_ALL_FIELDS Request all fields (default: false)")
@@ -287,6 +674,13 @@ func printUsage() {
 	fmt.Println("  dctrackcheck list RDU2                     # List all items in RDU2")
 	fmt.Println("  dctrackcheck item 12345                    # Get details for item 12345")
 	fmt.Println("  dctrackcheck power RDU2                    # Power analysis for RDU2")
+	fmt.Println("  dctrackcheck powerchain 12345              # Loads along the power chain of item 12345")
+	fmt.Println("  dctrackcheck locations RDU2                # Rooms, rows and cabinets in RDU2")
+	fmt.Println("  dctrackcheck ports 12345                   # Where each port of item 12345 ends up")
+	fmt.Println("  dctrackcheck where 'make in (Dell,HPE) and power > 500'")
+	fmt.Println("  dctrackcheck summary location,room         # Assets, kW and RUs per room of each site")
+	fmt.Println("  dctrackcheck --config config.yaml --profile production list RDU2")
+	fmt.Println("  dctrackcheck --dry-run import new-cage.csv   # Preview an import")
 }
 
 // Helper functions
@@ -114,6 +114,57 @@ func TestGetConfigFromEnv(t *testing.T) {
 	}
 }
 
+func TestLoadConfig(t *testing.T) {
+	for key := range saveEnvironment() {
+		os.Unsetenv(key)
+	}
+
+	t.Run("config file profile", func(t *testing.T) {
+		config, err := loadConfig("../../config.yaml", "integration")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if config.URL != "https://dctrack-dev.company.com/api/v2" {
+			t.Errorf("Expected integration URL, got %s", config.URL)
+		}
+		if config.PageSize != 100 {
+			t.Errorf("Expected PageSize 100, got %d", config.PageSize)
+		}
+		if config.PasswordFile == "" {
+			t.Errorf("Expected PasswordFile from config file")
+		}
+	})
+
+	t.Run("unknown profile", func(t *testing.T) {
+		if _, err := loadConfig("../../config.yaml", "staging"); err == nil {
+			t.Errorf("Expected error for unknown profile")
+		}
+	})
+
+	t.Run("environment only", func(t *testing.T) {
+		if _, err := loadConfig("", ""); err == nil {
+			t.Errorf("Expected error without DCTRACK_URL")
+		}
+
+		os.Setenv("DCTRACK_URL", "https://test.com/api/v2")
+		os.Setenv("DCTRACK_USERNAME", "testuser")
+		os.Setenv("DCTRACK_PASSWORD", "testpass")
+		defer func() {
+			os.Unsetenv("DCTRACK_URL")
+			os.Unsetenv("DCTRACK_USERNAME")
+			os.Unsetenv("DCTRACK_PASSWORD")
+		}()
+
+		config, err := loadConfig("", "")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if config.PageSize != defaultPageSize {
+			t.Errorf("Expected CLI default PageSize %d, got %d", defaultPageSize, config.PageSize)
+		}
+	})
+}
+
 func TestValidateConfig(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -299,9 +350,9 @@ func TestPrintFunctions(t *testing.T) {
 // Helper functions for TestMain
 func saveEnvironment() map[string]string {
 	envVars := []string{
-		"DCTRACK_URL", "DCTRACK_USERNAME", "DCTRACK_PASSWORD",
+		"DCTRACK_URL", "DCTRACK_USERNAME", "DCTRACK_PASSWORD", "DCTRACK_PASSWORD_FILE",
 		"DCTRACK_PAGE_SIZE", "DCTRACK_MAX_RETRIES", "DCTRACK_RETRY_DELAY",
-		"DCTRACK_VERIFY_SSL", "DCTRACK_ALL_FIELDS",
+		"DCTRACK_VERIFY_SSL", "DCTRACK_ALL_FIELDS", "DCTRACK_CONFIG", "DCTRACK_PROFILE",
 	}
 
 	saved := make(map[string]string)
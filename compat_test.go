@@ -0,0 +1,271 @@
+package dctrack
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	dct "github.com/Jethzabell/go-dctrack-client/dctrack"
+)
+
+// These cases pin the response shapes accepted by the root client and the
+// dctrack subpackage before they were merged. Both import paths must keep
+// decoding every one of them.
+var compatResponses = []struct {
+	name             string
+	body             string
+	facadeCount      int // Records returned by the root facade (strict mapping)
+	canonicalCount   int // Records returned by the dctrack subpackage
+	expectedPosition string
+}{
+	{
+		name: "quicksearch searchResults with cmbUPosition",
+		body: `{
+			"totalRows": 1, "pageNumber": 1, "pageSize": 1000,
+			"searchResults": {"items": [{
+				"id": "1001", "tiName": "web-01", "tiClass": "Device", "cmbStatus": "Installed",
+				"cmbLocation": "RDU2", "cmbCabinet": "A01", "cmbUPosition": "12",
+				"cmbMake": "Dell", "tiItemOriginalPower": 450
+			}]}
+		}`,
+		facadeCount:      1,
+		canonicalCount:   1,
+		expectedPosition: "12",
+	},
+	{
+		name: "flat records with cmbPosition",
+		body: `{"records": [{
+			"id": "1001", "tiName": "web-01", "tiClass": "Device", "cmbStatus": "Installed",
+			"cmbLocation": "RDU2", "cmbCabinet": "A01", "cmbPosition": "12",
+			"cmbMake": "Dell", "tiItemOriginalPower": "450"
+		}]}`,
+		facadeCount:      1,
+		canonicalCount:   1,
+		expectedPosition: "12",
+	},
+	{
+		name: "cmbUPosition wins over cmbPosition",
+		body: `{"records": [{
+			"id": "1001", "tiName": "web-01", "tiClass": "Device", "cmbStatus": "Installed",
+			"cmbLocation": "RDU2", "cmbUPosition": "12", "cmbPosition": "99",
+			"cmbMake": "Dell", "tiItemOriginalPower": 450
+		}]}`,
+		facadeCount:      1,
+		canonicalCount:   1,
+		expectedPosition: "12",
+	},
+	{
+		name:           "empty searchResults",
+		body:           `{"totalRows": 0, "searchResults": {"items": []}}`,
+		facadeCount:    0,
+		canonicalCount: 0,
+	},
+	{
+		name:           "empty records",
+		body:           `{"records": []}`,
+		facadeCount:    0,
+		canonicalCount: 0,
+	},
+	{
+		name: "record missing required fields",
+		body: `{"searchResults": {"items": [
+			{"id": "1001", "tiName": "web-01", "tiClass": "Device", "cmbStatus": "Installed",
+			 "cmbLocation": "RDU2", "cmbUPosition": "12", "cmbMake": "Dell", "tiItemOriginalPower": "450"},
+			{"id": "1002", "cmbMake": "HPE"}
+		]}}`,
+		facadeCount:      1,
+		canonicalCount:   2,
+		expectedPosition: "12",
+	},
+}
+
+func newCompatServer(body string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/authentication/login":
+			w.Header().Set("Authorization", "Bearer mock-token-12345")
+			w.WriteHeader(http.StatusOK)
+		case "/api/v2/quicksearch/items":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(body))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestCompatibilityResponseShapes(t *testing.T) {
+	for _, tt := range compatResponses {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newCompatServer(tt.body)
+			defer server.Close()
+
+			facade, err := New(server.URL+"/api/v2", "testuser", "testpass")
+			if err != nil {
+				t.Fatalf("Failed to create facade client: %v", err)
+			}
+			canonical, err := dct.New(server.URL+"/api/v2", "testuser", "testpass")
+			if err != nil {
+				t.Fatalf("Failed to create canonical client: %v", err)
+			}
+
+			clients := []struct {
+				name     string
+				getItems func(context.Context) ([]DCTrackItem, error)
+				expected int
+			}{
+				{"facade", facade.GetItems, tt.facadeCount},
+				{"canonical", canonical.GetItems, tt.canonicalCount},
+			}
+
+			for _, c := range clients {
+				items, err := c.getItems(context.Background())
+				if err != nil {
+					t.Fatalf("%s: GetItems failed: %v", c.name, err)
+				}
+				if len(items) != c.expected {
+					t.Fatalf("%s: Expected %d items, got %d", c.name, c.expected, len(items))
+				}
+				if len(items) == 0 {
+					continue
+				}
+
+				item := items[0]
+				if item.ID != "1001" || item.Name != "web-01" || item.Location != "RDU2" {
+					t.Errorf("%s: Unexpected core fields: %+v", c.name, item)
+				}
+				if item.Make != "Dell" {
+					t.Errorf("%s: Expected Make Dell, got %s", c.name, item.Make)
+				}
+				if item.OriginalPower != 450 {
+					t.Errorf("%s: Expected OriginalPower 450, got %f", c.name, item.OriginalPower)
+				}
+				if item.Position != tt.expectedPosition {
+					t.Errorf("%s: Expected Position %s, got %s", c.name, tt.expectedPosition, item.Position)
+				}
+			}
+		})
+	}
+}
+
+func TestCompatibilityStrictMappingOption(t *testing.T) {
+	server := newCompatServer(compatResponses[len(compatResponses)-1].body)
+	defer server.Close()
+
+	client, err := dct.New(server.URL+"/api/v2", "testuser", "testpass", dct.WithStrictMapping())
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	items, err := client.GetItems(context.Background())
+	if err != nil {
+		t.Fatalf("GetItems failed: %v", err)
+	}
+	if len(items) != 1 {
+		t.Errorf("Expected incomplete record to be dropped, got %d items", len(items))
+	}
+}
+
+func TestCompatibilitySharedTypes(t *testing.T) {
+	// Values built against one import path must be usable with the other
+	var config Config = dct.DefaultConfig("https://test.com/api/v2", "user", "pass")
+	var params dct.ItemsParams = ByLocation("RDU2")
+
+	if _, err := NewClient(config); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if params.Location != "RDU2" {
+		t.Errorf("Expected Location RDU2, got %s", params.Location)
+	}
+}
+
+func TestCompatibilityFacadeSelectsColumns(t *testing.T) {
+	// The root client used to send an empty payload; it now selects the
+	// standard columns like the dctrack subpackage
+	tests := []struct {
+		name      string
+		allFields bool
+		serial    bool
+	}{
+		{"all fields", true, true},
+		{"minimal fields", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var columns []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/api/v2/authentication/login":
+					w.Header().Set("Authorization", "Bearer mock-token-12345")
+				case "/api/v2/quicksearch/items":
+					var payload struct {
+						SelectedColumns []struct {
+							Name string `json:"name"`
+						} `json:"selectedColumns"`
+					}
+					json.NewDecoder(r.Body).Decode(&payload)
+					for _, column := range payload.SelectedColumns {
+						columns = append(columns, column.Name)
+					}
+					w.Write([]byte(`{"searchResults": {"items": []}}`))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			config := dct.DefaultConfig(server.URL+"/api/v2", "testuser", "testpass")
+			config.RequestAllFields = tt.allFields
+			client, err := NewClient(config)
+			if err != nil {
+				t.Fatalf("Failed to create client: %v", err)
+			}
+			if _, err := client.GetItems(context.Background()); err != nil {
+				t.Fatalf("GetItems failed: %v", err)
+			}
+
+			if !slices.Contains(columns, "tiName") || !slices.Contains(columns, "cmbLocation") {
+				t.Errorf("Expected the standard columns to be selected, got %v", columns)
+			}
+			if slices.Contains(columns, "tiSerialNumber") != tt.serial {
+				t.Errorf("Expected tiSerialNumber selected to be %t, got %v", tt.serial, columns)
+			}
+		})
+	}
+}
+
+func TestCompatibilityNumberParsing(t *testing.T) {
+	// Numbers are parsed whole; the root client used to read the leading
+	// number of values such as "450W"
+	server := newCompatServer(`{"searchResults": {"items": [{
+		"id": "1001", "tiName": "web-01", "tiClass": "Device", "cmbStatus": "Installed",
+		"cmbLocation": "RDU2", "tiItemOriginalPower": "450W", "tiRUs": "2U"
+	}, {
+		"id": "1002", "tiName": "web-02", "tiClass": "Device", "cmbStatus": "Installed",
+		"cmbLocation": "RDU2", "tiItemOriginalPower": "4.5e2", "tiRUs": "2"
+	}]}}`)
+	defer server.Close()
+
+	client, err := New(server.URL+"/api/v2", "testuser", "testpass")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	items, err := client.GetItems(context.Background())
+	if err != nil {
+		t.Fatalf("GetItems failed: %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("Expected 2 items, got %d", len(items))
+	}
+
+	if items[0].OriginalPower != 0 || items[0].Height != 0 {
+		t.Errorf("Expected values with units to parse as 0, got power %f and height %d", items[0].OriginalPower, items[0].Height)
+	}
+	if items[1].OriginalPower != 450 || items[1].Height != 2 {
+		t.Errorf("Expected power 450 and height 2, got %f and %d", items[1].OriginalPower, items[1].Height)
+	}
+}
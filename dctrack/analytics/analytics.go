@@ -0,0 +1,265 @@
+// Package analytics summarizes DCTrack inventories: asset counts, power,
+// rack units, purchase value and weight per site, room, row, cabinet,
+// admin team or customer. It also plans refreshes, audits data quality,
+// breaks inventories down by vendor and finds room in cabinets.
+package analytics
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Jethzabell/go-dctrack-client/dctrack"
+)
+
+// GroupKey is an item attribute summaries can be grouped by
+type GroupKey string
+
+const (
+	ByLocation  GroupKey = "location"
+	ByRoom      GroupKey = "room"
+	ByRow       GroupKey = "row"
+	ByCabinet   GroupKey = "cabinet"
+	ByAdminTeam GroupKey = "admin_team"
+	ByCustomer  GroupKey = "customer"
+)
+
+// groupKeys maps each key to the item value and the column it is read from
+var groupKeys = map[GroupKey]struct {
+	column string
+	value  func(item dctrack.DCTrackItem) string
+}{
+	ByLocation:  {"cmbLocation", func(item dctrack.DCTrackItem) string { return item.Location }},
+	ByRoom:      {"tiRoomName", func(item dctrack.DCTrackItem) string { return item.TiRoomName }},
+	ByRow:       {"cmbRowLabel", func(item dctrack.DCTrackItem) string { return item.CmbRowLabel }},
+	ByCabinet:   {"cmbCabinet", func(item dctrack.DCTrackItem) string { return item.Cabinet }},
+	ByAdminTeam: {"cmbSystemAdminTeam", func(item dctrack.DCTrackItem) string { return item.SystemAdminTeam }},
+	ByCustomer:  {"cmbCustomer", func(item dctrack.DCTrackItem) string { return item.CmbCustomer }},
+}
+
+// Options controls how items are summarized
+type Options struct {
+	// Keys to group by, outermost first (default: ByLocation)
+	GroupBy []GroupKey
+
+	// Power figure to total: dctrack.PowerOriginal (the default),
+	// dctrack.PowerConsumption or dctrack.PowerPotential
+	PowerType string
+}
+
+// Summary is the roll-up of one group of items. Location is set when the
+// grouping includes ByLocation.
+type Summary struct {
+	// Values of the Options.GroupBy keys, in order; "" for items without one
+	Key []string `json:"key"`
+
+	dctrack.AnalyticsSummary
+
+	PowerAssets int `json:"power_assets"` // Assets reporting power
+	RUAssets    int `json:"ru_assets"`    // Assets with a known height
+}
+
+// Label joins the group's key values, showing missing ones as "(none)"
+func (s Summary) Label() string {
+	parts := make([]string, len(s.Key))
+	for i, value := range s.Key {
+		if value == "" {
+			value = "(none)"
+		}
+		parts[i] = value
+	}
+	return strings.Join(parts, " / ")
+}
+
+// accumulator collects the totals of a group before averages are taken
+type accumulator struct {
+	summary      Summary
+	power        float64 // Watts
+	powerWithRU  float64 // Watts of assets with a known height
+	rusWithPower int     // Rack units of assets reporting power
+}
+
+// Summarize groups items by opts.GroupBy and totals each group, sorted by
+// key with missing values last. Key values are compared case-insensitively
+// and shown as first seen. Missing, zero or negative power, height,
+// price and weight contribute nothing: AveragePowerPerAsset is taken over
+// the assets reporting power, and PowerDensityPerRU over the assets
+// reporting both power and height.
+func Summarize(items []dctrack.DCTrackItem, opts Options) ([]Summary, error) {
+	keys, err := groupBy(opts)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := dctrack.PowerOf(dctrack.DCTrackItem{}, opts.PowerType); err != nil {
+		return nil, err
+	}
+
+	groups := make(map[string]*accumulator)
+	var order []*accumulator
+	for _, item := range items {
+		key := make([]string, len(keys))
+		for i, k := range keys {
+			key[i] = strings.TrimSpace(groupKeys[k].value(item))
+		}
+		id := strings.ToLower(strings.Join(key, "\x00"))
+
+		group, ok := groups[id]
+		if !ok {
+			group = &accumulator{summary: Summary{Key: key}}
+			for i, k := range keys {
+				if k == ByLocation {
+					group.summary.Location = key[i]
+				}
+			}
+			groups[id] = group
+			order = append(order, group)
+		}
+		group.add(item, opts.PowerType)
+	}
+
+	summaries := make([]Summary, len(order))
+	for i, group := range order {
+		summaries[i] = group.finish()
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return lessKey(summaries[i].Key, summaries[j].Key)
+	})
+	return summaries, nil
+}
+
+// SummarizeBySite totals items per location
+func SummarizeBySite(items []dctrack.DCTrackItem) []dctrack.AnalyticsSummary {
+	summaries, _ := Summarize(items, Options{GroupBy: []GroupKey{ByLocation}})
+	return siteSummaries(summaries)
+}
+
+// siteSummaries drops the grouping details of per-location summaries
+func siteSummaries(summaries []Summary) []dctrack.AnalyticsSummary {
+	sites := make([]dctrack.AnalyticsSummary, len(summaries))
+	for i, summary := range summaries {
+		sites[i] = summary.AnalyticsSummary
+	}
+	return sites
+}
+
+// add counts an item into the group
+func (a *accumulator) add(item dctrack.DCTrackItem, powerType string) {
+	s := &a.summary
+	s.TotalAssets++
+
+	power, _ := dctrack.PowerOf(item, powerType)
+	if power > 0 {
+		a.power += power
+		s.PowerAssets++
+	}
+	if item.Height > 0 {
+		s.TotalRUs += item.Height
+		s.RUAssets++
+		if power > 0 {
+			a.powerWithRU += power
+			a.rusWithPower += item.Height
+		}
+	}
+	if item.TiPurchasePrice > 0 {
+		s.TotalPurchaseValue += item.TiPurchasePrice
+	}
+	if item.TiWeight > 0 {
+		s.TotalWeight += item.TiWeight
+	}
+}
+
+// finish derives the totals in kW and the averages
+func (a *accumulator) finish() Summary {
+	s := a.summary
+	s.TotalPowerKW = a.power / 1000
+	if s.PowerAssets > 0 {
+		s.AveragePowerPerAsset = a.power / float64(s.PowerAssets)
+	}
+	if a.rusWithPower > 0 {
+		s.PowerDensityPerRU = a.powerWithRU / float64(a.rusWithPower)
+	}
+	return s
+}
+
+// groupBy validates the grouping keys, defaulting to ByLocation
+func groupBy(opts Options) ([]GroupKey, error) {
+	if len(opts.GroupBy) == 0 {
+		return []GroupKey{ByLocation}, nil
+	}
+	for _, key := range opts.GroupBy {
+		if _, ok := groupKeys[key]; !ok {
+			return nil, fmt.Errorf("unknown group key %q", key)
+		}
+	}
+	return opts.GroupBy, nil
+}
+
+// lessKey orders keys value by value, case-insensitively, with missing
+// values after present ones
+func lessKey(a, b []string) bool {
+	for i := range a {
+		if a[i] == b[i] {
+			continue
+		}
+		if a[i] == "" || b[i] == "" {
+			return b[i] == ""
+		}
+		if x, y := strings.ToLower(a[i]), strings.ToLower(b[i]); x != y {
+			return x < y
+		}
+		return a[i] < b[i]
+	}
+	return false
+}
+
+// ItemSource fetches items; *dctrack.Client satisfies it
+type ItemSource interface {
+	GetItemsWithParams(ctx context.Context, params dctrack.ItemsParams) ([]dctrack.DCTrackItem, error)
+}
+
+// Client fetches and summarizes items in one call
+type Client struct {
+	source ItemSource
+}
+
+// NewClient wraps a DCTrack client
+func NewClient(source ItemSource) *Client {
+	return &Client{source: source}
+}
+
+// Summarize fetches the items matching params and summarizes them. Unless
+// params.Columns is set, only the columns the summary reads are requested.
+func (c *Client) Summarize(ctx context.Context, params dctrack.ItemsParams, opts Options) ([]Summary, error) {
+	keys, err := groupBy(opts)
+	if err != nil {
+		return nil, err
+	}
+	column := dctrack.PowerColumn(opts.PowerType)
+	if column == "" {
+		return nil, fmt.Errorf("unknown power type %q", opts.PowerType)
+	}
+
+	if len(params.Columns) == 0 {
+		params.Columns = []string{column, "tiRUs", "tiPurchasePrice", "tiWeight"}
+		for _, key := range keys {
+			params.Columns = append(params.Columns, groupKeys[key].column)
+		}
+	}
+
+	items, err := c.source.GetItemsWithParams(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching items to summarize: %w", err)
+	}
+	return Summarize(items, opts)
+}
+
+// SummarizeBySite fetches the items matching params and totals them per
+// location
+func (c *Client) SummarizeBySite(ctx context.Context, params dctrack.ItemsParams) ([]dctrack.AnalyticsSummary, error) {
+	summaries, err := c.Summarize(ctx, params, Options{GroupBy: []GroupKey{ByLocation}})
+	if err != nil {
+		return nil, err
+	}
+	return siteSummaries(summaries), nil
+}
@@ -0,0 +1,207 @@
+package analytics
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Jethzabell/go-dctrack-client/dctrack"
+)
+
+var update = flag.Bool("update", false, "Rewrite the golden files in testdata")
+
+func loadItems(t *testing.T) []dctrack.DCTrackItem {
+	t.Helper()
+	return loadItemsFile(t, "items.json")
+}
+
+// loadItemsFile decodes the items in testdata/name
+func loadItemsFile(t *testing.T, name string) []dctrack.DCTrackItem {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("Failed to read items: %v", err)
+	}
+	var items []dctrack.DCTrackItem
+	if err := json.Unmarshal(data, &items); err != nil {
+		t.Fatalf("Failed to decode items: %v", err)
+	}
+	return items
+}
+
+// checkGolden compares got, as indented JSON, with testdata/name.golden
+func checkGolden(t *testing.T, name string, got interface{}) {
+	t.Helper()
+	actual, err := json.MarshalIndent(got, "", "  ")
+	if err != nil {
+		t.Fatalf("Failed to encode result: %v", err)
+	}
+	actual = append(actual, '\n')
+
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.WriteFile(path, actual, 0o644); err != nil {
+			t.Fatalf("Failed to update %s: %v", path, err)
+		}
+	}
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read %s (run with -update to create it): %v", path, err)
+	}
+	if !bytes.Equal(actual, expected) {
+		t.Errorf("Result differs from %s:\n%s", path, actual)
+	}
+}
+
+func TestSummarizeGolden(t *testing.T) {
+	items := loadItems(t)
+
+	t.Run("by_site", func(t *testing.T) {
+		checkGolden(t, "by_site", SummarizeBySite(items))
+	})
+
+	tests := []struct {
+		name string
+		opts Options
+	}{
+		{"by_room_row", Options{GroupBy: []GroupKey{ByLocation, ByRoom, ByRow}}},
+		{"by_cabinet", Options{GroupBy: []GroupKey{ByCabinet}}},
+		{"by_team", Options{GroupBy: []GroupKey{ByAdminTeam}}},
+		{"by_customer_consumption", Options{GroupBy: []GroupKey{ByCustomer}, PowerType: dctrack.PowerConsumption}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summaries, err := Summarize(items, tt.opts)
+			if err != nil {
+				t.Fatalf("Summarize failed: %v", err)
+			}
+			checkGolden(t, tt.name, summaries)
+		})
+	}
+}
+
+func TestSummarizeEdgeCases(t *testing.T) {
+	summaries, err := Summarize(nil, Options{})
+	if err != nil || len(summaries) != 0 {
+		t.Errorf("Expected no summaries for no items, got %v (%v)", summaries, err)
+	}
+
+	// Assets without power or height must not produce NaN averages
+	summaries, _ = Summarize([]dctrack.DCTrackItem{{ID: "1", Location: "RDU2"}}, Options{})
+	if len(summaries) != 1 || summaries[0].AveragePowerPerAsset != 0 || summaries[0].PowerDensityPerRU != 0 {
+		t.Errorf("Expected zero averages, got %+v", summaries)
+	}
+	if label := (Summary{Key: []string{"RDU2", ""}}).Label(); label != "RDU2 / (none)" {
+		t.Errorf("Expected label RDU2 / (none), got %s", label)
+	}
+
+	if _, err := Summarize(nil, Options{GroupBy: []GroupKey{"planet"}}); err == nil {
+		t.Errorf("Expected error for an unknown group key")
+	}
+	if _, err := Summarize(nil, Options{PowerType: "peak"}); err == nil {
+		t.Errorf("Expected error for an unknown power type")
+	}
+}
+
+type fakeSource struct {
+	params dctrack.ItemsParams
+	items  []dctrack.DCTrackItem
+	err    error
+
+	// Return only the fields of params.Columns, as DCTrack does
+	projected bool
+}
+
+func (s *fakeSource) GetItemsWithParams(ctx context.Context, params dctrack.ItemsParams) ([]dctrack.DCTrackItem, error) {
+	s.params = params
+	if s.err != nil || !s.projected || len(params.Columns) == 0 {
+		return s.items, s.err
+	}
+	return projectItems(s.items, params.Columns)
+}
+
+// itemKeys maps columns to the JSON keys of the item fields they fill
+var itemKeys = map[string]string{
+	"tiName": "name", "tiClass": "item_class", "cmbStatus": "status", "cmbLocation": "location",
+	"cmbCabinet": "cabinet", "cmbUPosition": "position", "tiRUs": "height", "tiMounting": "ti_mounting",
+	"radioRailsUsed": "rails_used", "tiEffectivePower": "ti_effective_power",
+	"tiPowerCapacity": "ti_power_capacity", "tiWeight": "ti_weight", "tiRoomName": "ti_room_name",
+	"cmbRowLabel": "cmb_row_label",
+}
+
+// projectItems clears the fields of items that columns do not fill
+func projectItems(items []dctrack.DCTrackItem, columns []string) ([]dctrack.DCTrackItem, error) {
+	projected := make([]dctrack.DCTrackItem, len(items))
+	for i, item := range items {
+		data, err := json.Marshal(item)
+		if err != nil {
+			return nil, err
+		}
+		var full map[string]interface{}
+		if err := json.Unmarshal(data, &full); err != nil {
+			return nil, err
+		}
+
+		record := map[string]interface{}{"id": full["id"]}
+		fields, _ := full["custom_fields"].(map[string]interface{})
+		custom := make(map[string]interface{})
+		for _, column := range columns {
+			if name, ok := strings.CutPrefix(column, dctrack.CustomFieldColumn("")); ok {
+				if value, ok := fields[name]; ok {
+					custom[name] = value
+				}
+			} else if key, ok := itemKeys[column]; ok {
+				record[key] = full[key]
+			}
+		}
+		record["custom_fields"] = custom
+
+		if data, err = json.Marshal(record); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &projected[i]); err != nil {
+			return nil, err
+		}
+	}
+	return projected, nil
+}
+
+func TestClientSummarize(t *testing.T) {
+	source := &fakeSource{items: loadItems(t)}
+	client := NewClient(source)
+	ctx := context.Background()
+
+	sites, err := client.SummarizeBySite(ctx, dctrack.InstalledOnly())
+	if err != nil {
+		t.Fatalf("SummarizeBySite failed: %v", err)
+	}
+	if !reflect.DeepEqual(sites, SummarizeBySite(source.items)) {
+		t.Errorf("Expected the same summary as SummarizeBySite, got %+v", sites)
+	}
+
+	expected := []string{"tiItemOriginalPower", "tiRUs", "tiPurchasePrice", "tiWeight", "cmbLocation"}
+	if source.params.Status != "Installed" || !reflect.DeepEqual(source.params.Columns, expected) {
+		t.Errorf("Expected installed items with columns %v, got %+v", expected, source.params)
+	}
+
+	_, err = client.Summarize(ctx, dctrack.ItemsParams{Columns: []string{"tiName"}},
+		Options{GroupBy: []GroupKey{ByCabinet}, PowerType: dctrack.PowerPotential})
+	if err != nil {
+		t.Fatalf("Summarize failed: %v", err)
+	}
+	if !reflect.DeepEqual(source.params.Columns, []string{"tiName"}) {
+		t.Errorf("Expected the caller's columns to be kept, got %v", source.params.Columns)
+	}
+
+	source.err = dctrack.ErrNotFound
+	if _, err := client.SummarizeBySite(ctx, dctrack.ItemsParams{}); !errors.Is(err, dctrack.ErrNotFound) {
+		t.Errorf("Expected the fetch error, got %v", err)
+	}
+}
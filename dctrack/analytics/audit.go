@@ -0,0 +1,291 @@
+package analytics
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Jethzabell/go-dctrack-client/dctrack"
+)
+
+// Custom fields read by the contact rules
+const (
+	primaryContactField = "Primary Contact"
+	execSponsorField    = "Exec Sponsor"
+)
+
+// Names of the built-in rules
+const (
+	RuleMissingPrimaryContact = "missing-primary-contact"
+	RuleMissingExecSponsor    = "missing-exec-sponsor"
+	RuleMissingAdminTeam      = "missing-admin-team"
+	RuleMissingWarranty       = "missing-warranty-expiration"
+	RuleMissingSerial         = "missing-serial-number"
+	RuleMissingAssetTag       = "missing-asset-tag"
+	RuleDuplicateSerial       = "duplicate-serial-number"
+	RuleInstalledUnplaced     = "installed-without-placement"
+	RuleFutureInstallDate     = "future-install-date"
+	RuleStaleAudit            = "stale-audit-date"
+)
+
+// Rule is a data-quality check over an inventory
+type Rule interface {
+	Name() string
+	// Failing returns the indexes of the items that fail the check
+	Failing(items []dctrack.DCTrackItem) []int
+}
+
+// itemRule checks each item on its own
+type itemRule struct {
+	name  string
+	fails func(item dctrack.DCTrackItem) bool
+}
+
+// NewRule creates a rule that fails the items for which fails is true
+func NewRule(name string, fails func(item dctrack.DCTrackItem) bool) Rule {
+	return itemRule{name: name, fails: fails}
+}
+
+func (r itemRule) Name() string {
+	return r.name
+}
+
+func (r itemRule) Failing(items []dctrack.DCTrackItem) []int {
+	var failing []int
+	for i, item := range items {
+		if r.fails(item) {
+			failing = append(failing, i)
+		}
+	}
+	return failing
+}
+
+// duplicateSerialRule fails every item sharing its serial number with
+// another, compared case-insensitively
+type duplicateSerialRule struct{}
+
+func (duplicateSerialRule) Name() string {
+	return RuleDuplicateSerial
+}
+
+func (duplicateSerialRule) Failing(items []dctrack.DCTrackItem) []int {
+	bySerial := make(map[string][]int)
+	for i, item := range items {
+		if serial := strings.ToLower(serialNumber(item)); serial != "" {
+			bySerial[serial] = append(bySerial[serial], i)
+		}
+	}
+
+	var failing []int
+	for _, indexes := range bySerial {
+		if len(indexes) > 1 {
+			failing = append(failing, indexes...)
+		}
+	}
+	sort.Ints(failing)
+	return failing
+}
+
+// DefaultRules returns the built-in rules. Install dates after now fail,
+// as do audit dates older than auditMaxAge or missing.
+func DefaultRules(now time.Time, auditMaxAge time.Duration) []Rule {
+	return []Rule{
+		NewRule(RuleMissingPrimaryContact, func(item dctrack.DCTrackItem) bool { return primaryContact(item) == "" }),
+		NewRule(RuleMissingExecSponsor, func(item dctrack.DCTrackItem) bool { return customText(item, execSponsorField) == "" }),
+		NewRule(RuleMissingAdminTeam, func(item dctrack.DCTrackItem) bool { return strings.TrimSpace(item.SystemAdminTeam) == "" }),
+		NewRule(RuleMissingWarranty, func(item dctrack.DCTrackItem) bool { return warrantyEnd(item) == nil }),
+		NewRule(RuleMissingSerial, func(item dctrack.DCTrackItem) bool { return serialNumber(item) == "" }),
+		NewRule(RuleMissingAssetTag, func(item dctrack.DCTrackItem) bool { return strings.TrimSpace(item.TiAssetTag) == "" }),
+		duplicateSerialRule{},
+		NewRule(RuleInstalledUnplaced, func(item dctrack.DCTrackItem) bool {
+			// Cabinets are placed on the floor, not in a cabinet
+			return strings.EqualFold(item.Status, "Installed") && !strings.EqualFold(item.ItemClass, "Cabinet") &&
+				(strings.TrimSpace(item.Cabinet) == "" || strings.TrimSpace(item.Position) == "")
+		}),
+		NewRule(RuleFutureInstallDate, func(item dctrack.DCTrackItem) bool {
+			installed := installDate(item)
+			return installed != nil && installed.After(now)
+		}),
+		NewRule(RuleStaleAudit, func(item dctrack.DCTrackItem) bool {
+			audited := auditDate(item)
+			return audited == nil || now.Sub(*audited) > auditMaxAge
+		}),
+	}
+}
+
+// AuditOptions controls an audit
+type AuditOptions struct {
+	// Rules to run (default: DefaultRules with Now and AuditMaxAge)
+	Rules []Rule
+	// Time install and audit dates are compared with (default: now)
+	Now time.Time
+	// Maximum age of an audit date (default: one year)
+	AuditMaxAge time.Duration
+	// Columns custom rules read, requested by Client.Audit in addition to
+	// those the built-in rules read
+	Columns []string
+}
+
+// RuleResult lists the items failing one rule
+type RuleResult struct {
+	Rule    string   `json:"rule"`
+	Count   int      `json:"count"`
+	ItemIDs []string `json:"item_ids"`
+}
+
+// AuditScore rates the data quality of one location or team
+type AuditScore struct {
+	Group         string  `json:"group"` // "" for items without one
+	Assets        int     `json:"assets"`
+	FailingAssets int     `json:"failing_assets"` // Assets failing any rule
+	Failures      int     `json:"failures"`       // Failed checks
+	Score         float64 `json:"score"`          // Percentage of checks passed
+}
+
+// AuditReport is the outcome of an audit
+type AuditReport struct {
+	dctrack.ContactAudit
+	Rules      []RuleResult `json:"rules"`
+	ByLocation []AuditScore `json:"by_location"`
+	ByTeam     []AuditScore `json:"by_team"`
+}
+
+// Audit runs the rules of opts over items, reporting the failing item IDs
+// per rule, the contact totals and a score per location and admin team
+func Audit(items []dctrack.DCTrackItem, opts AuditOptions) AuditReport {
+	rules := opts.Rules
+	if rules == nil {
+		now := opts.Now
+		if now.IsZero() {
+			now = time.Now()
+		}
+		maxAge := opts.AuditMaxAge
+		if maxAge == 0 {
+			maxAge = 365 * 24 * time.Hour
+		}
+		rules = DefaultRules(now, maxAge)
+	}
+
+	report := AuditReport{ContactAudit: dctrack.ContactAudit{TotalAssets: len(items)}}
+	for _, item := range items {
+		if primaryContact(item) == "" {
+			report.MissingPrimary++
+		}
+		if customText(item, execSponsorField) == "" {
+			report.MissingExecSponsor++
+		}
+		if warrantyEnd(item) == nil {
+			report.MissingWarranty++
+		}
+	}
+
+	failures := make([]int, len(items))
+	for _, rule := range rules {
+		result := RuleResult{Rule: rule.Name(), ItemIDs: []string{}}
+		for _, i := range rule.Failing(items) {
+			failures[i]++
+			result.Count++
+			result.ItemIDs = append(result.ItemIDs, items[i].ID)
+		}
+		report.Rules = append(report.Rules, result)
+	}
+
+	report.ByLocation = auditScores(items, failures, len(rules), func(item dctrack.DCTrackItem) string { return item.Location })
+	report.ByTeam = auditScores(items, failures, len(rules), func(item dctrack.DCTrackItem) string { return item.SystemAdminTeam })
+	return report
+}
+
+// auditScores totals failures per group, sorted by group with items
+// without one last
+func auditScores(items []dctrack.DCTrackItem, failures []int, rules int, group func(dctrack.DCTrackItem) string) []AuditScore {
+	byGroup := make(map[string]*AuditScore)
+	var scores []*AuditScore
+	for i, item := range items {
+		name := strings.TrimSpace(group(item))
+		score, ok := byGroup[strings.ToLower(name)]
+		if !ok {
+			score = &AuditScore{Group: name}
+			byGroup[strings.ToLower(name)] = score
+			scores = append(scores, score)
+		}
+		score.Assets++
+		score.Failures += failures[i]
+		if failures[i] > 0 {
+			score.FailingAssets++
+		}
+	}
+
+	result := make([]AuditScore, len(scores))
+	for i, score := range scores {
+		score.Score = 100
+		if checks := score.Assets * rules; checks > 0 {
+			score.Score = 100 * float64(checks-score.Failures) / float64(checks)
+		}
+		result[i] = *score
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return lessKey([]string{result[i].Group}, []string{result[j].Group})
+	})
+	return result
+}
+
+// Audit fetches the items matching params and audits them. Unless
+// params.Columns is set, only the columns the built-in rules and
+// opts.Columns read are requested.
+func (c *Client) Audit(ctx context.Context, params dctrack.ItemsParams, opts AuditOptions) (*AuditReport, error) {
+	if len(params.Columns) == 0 {
+		params.Columns = append([]string{"tiClass", "cmbStatus", "cmbLocation", "cmbSystemAdminTeam",
+			"cmbCabinet", "cmbUPosition", "tiSerialNumber", "tiAssetTag", "installationDate",
+			dctrack.CustomFieldColumn(primaryContactField), dctrack.CustomFieldColumn(execSponsorField),
+			dctrack.CustomFieldColumn(warrantyField), dctrack.CustomFieldColumn("Audit Date")}, opts.Columns...)
+	}
+
+	items, err := c.source.GetItemsWithParams(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching items to audit: %w", err)
+	}
+	report := Audit(items, opts)
+	return &report, nil
+}
+
+// primaryContact returns an item's primary contact, if any
+func primaryContact(item dctrack.DCTrackItem) string {
+	if contact := strings.TrimSpace(item.PrimaryContact); contact != "" {
+		return contact
+	}
+	if item.TiCustomFieldPrimaryContact != nil {
+		if contact := strings.TrimSpace(*item.TiCustomFieldPrimaryContact); contact != "" {
+			return contact
+		}
+	}
+	return customText(item, primaryContactField)
+}
+
+// serialNumber returns an item's serial number, if any
+func serialNumber(item dctrack.DCTrackItem) string {
+	if serial := strings.TrimSpace(item.SerialNumber); serial != "" {
+		return serial
+	}
+	return strings.TrimSpace(item.TiSerialNumber)
+}
+
+// auditDate returns when an item was last audited, if known
+func auditDate(item dctrack.DCTrackItem) *time.Time {
+	if item.TiCustomFieldAuditDate != nil {
+		return item.TiCustomFieldAuditDate
+	}
+	if value, ok := item.CustomField("Audit Date"); ok && !value.IsZero() {
+		if audited, err := value.Time(); err == nil {
+			return &audited
+		}
+	}
+	return nil
+}
+
+// customText returns a custom field as trimmed text
+func customText(item dctrack.DCTrackItem, name string) string {
+	value, _ := item.CustomField(name)
+	return strings.TrimSpace(value.String())
+}
@@ -0,0 +1,110 @@
+package analytics
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Jethzabell/go-dctrack-client/dctrack"
+)
+
+func loadAuditItems(t *testing.T) []dctrack.DCTrackItem {
+	t.Helper()
+	return loadItemsFile(t, "audit_items.json")
+}
+
+var testAuditNow = time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+func TestAuditGolden(t *testing.T) {
+	checkGolden(t, "audit", Audit(loadAuditItems(t), AuditOptions{Now: testAuditNow}))
+}
+
+func TestAuditRules(t *testing.T) {
+	report := Audit(loadAuditItems(t), AuditOptions{Now: testAuditNow})
+
+	expected := map[string][]string{
+		RuleMissingPrimaryContact: {"6"},
+		RuleMissingExecSponsor:    {"2", "4", "6"},
+		RuleMissingAdminTeam:      {"6"},
+		RuleMissingWarranty:       {"2", "4", "6"},
+		RuleMissingSerial:         {},
+		RuleMissingAssetTag:       {"2", "6"},
+		RuleDuplicateSerial:       {"1", "2", "5", "6"},
+		RuleInstalledUnplaced:     {"2", "6"},
+		RuleFutureInstallDate:     {"2"},
+		RuleStaleAudit:            {"2", "6"},
+	}
+	if len(report.Rules) != len(expected) {
+		t.Fatalf("Expected %d rules, got %d", len(expected), len(report.Rules))
+	}
+	for _, result := range report.Rules {
+		if ids := expected[result.Rule]; !reflect.DeepEqual(result.ItemIDs, ids) || result.Count != len(ids) {
+			t.Errorf("Expected %s to fail %v, got %v (%d)", result.Rule, ids, result.ItemIDs, result.Count)
+		}
+	}
+
+	contacts := dctrack.ContactAudit{TotalAssets: 6, MissingPrimary: 1, MissingExecSponsor: 3, MissingWarranty: 3}
+	if report.ContactAudit != contacts {
+		t.Errorf("Expected contact audit %+v, got %+v", contacts, report.ContactAudit)
+	}
+}
+
+func TestAuditCustomRules(t *testing.T) {
+	items := loadAuditItems(t)
+	noTag := NewRule("missing-asset-tag-bos2", func(item dctrack.DCTrackItem) bool {
+		return item.Location == "BOS2" && item.TiAssetTag == ""
+	})
+	sponsor := NewRule("sponsor-is-contact", func(item dctrack.DCTrackItem) bool {
+		return strings.EqualFold(customText(item, execSponsorField), primaryContact(item))
+	})
+
+	report := Audit(items, AuditOptions{Rules: []Rule{noTag, sponsor}})
+	if len(report.Rules) != 2 || report.Rules[0].Count != 0 || !reflect.DeepEqual(report.Rules[1].ItemIDs, []string{"6"}) {
+		t.Errorf("Expected only item 6 to fail, got %+v", report.Rules)
+	}
+
+	// One failed check out of two for the item without a location
+	last := report.ByLocation[len(report.ByLocation)-1]
+	if last.Group != "" || last.Assets != 1 || last.Score != 50 {
+		t.Errorf("Expected a score of 50 for items without a location, got %+v", last)
+	}
+	if report.ByLocation[0].Group != "BOS2" || report.ByLocation[0].Score != 100 {
+		t.Errorf("Expected a perfect score for BOS2, got %+v", report.ByLocation[0])
+	}
+
+	// Custom rules may be added to the built-in ones
+	rules := append(DefaultRules(testAuditNow, 60*24*time.Hour), sponsor)
+	report = Audit(items, AuditOptions{Rules: rules})
+	if len(report.Rules) != 11 {
+		t.Errorf("Expected 11 rules, got %d", len(report.Rules))
+	}
+	if stale := report.Rules[9]; !reflect.DeepEqual(stale.ItemIDs, []string{"2", "3", "4", "6"}) {
+		t.Errorf("Expected items 2, 3, 4 and 6 to have a stale audit, got %v", stale.ItemIDs)
+	}
+}
+
+func TestClientAudit(t *testing.T) {
+	source := &fakeSource{items: loadAuditItems(t)}
+	client := NewClient(source)
+	ctx := context.Background()
+
+	report, err := client.Audit(ctx, dctrack.ItemsParams{Location: "RDU2"}, AuditOptions{Now: testAuditNow, Columns: []string{"tiName"}})
+	if err != nil {
+		t.Fatalf("Audit failed: %v", err)
+	}
+	if !reflect.DeepEqual(*report, Audit(source.items, AuditOptions{Now: testAuditNow})) {
+		t.Errorf("Expected the same report as Audit, got %+v", report)
+	}
+	columns := source.params.Columns
+	if len(columns) == 0 || columns[len(columns)-1] != "tiName" || source.params.Location != "RDU2" {
+		t.Errorf("Expected the rule columns plus tiName for RDU2, got %+v", source.params)
+	}
+
+	source.err = dctrack.ErrNotFound
+	if _, err := client.Audit(ctx, dctrack.ItemsParams{}, AuditOptions{}); !errors.Is(err, dctrack.ErrNotFound) {
+		t.Errorf("Expected the fetch error, got %v", err)
+	}
+}
@@ -0,0 +1,254 @@
+package analytics
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Jethzabell/go-dctrack-client/dctrack"
+)
+
+// weightCapacityField is the custom field holding a cabinet's load rating
+const weightCapacityField = "Weight Capacity"
+
+// CapacityOptions controls how cabinet capacity is computed
+type CapacityOptions struct {
+	// Power capacity in watts of cabinets without a tiPowerCapacity;
+	// 0 leaves their power headroom unknown
+	PowerCapacity float64
+	// Load rating of cabinets without a "Weight Capacity" custom field;
+	// 0 leaves their weight headroom unknown
+	WeightCapacity float64
+}
+
+// CabinetCapacity is the space, power and weight used and left in one
+// cabinet. Capacities of 0 are unknown, and their headroom is left at 0.
+type CabinetCapacity struct {
+	dctrack.CabinetUtilization
+
+	CabinetID string `json:"cabinet_id"`
+	Room      string `json:"room"`
+	Row       string `json:"row"`
+
+	PowerCapacity float64 `json:"power_capacity"` // Watts
+	PowerUsed     float64 `json:"power_used"`     // Summed EffectivePower of the contents
+	PowerHeadroom float64 `json:"power_headroom"`
+
+	WeightCapacity float64 `json:"weight_capacity"`
+	WeightUsed     float64 `json:"weight_used"` // Summed weight of the contents
+	WeightHeadroom float64 `json:"weight_headroom"`
+
+	// Runs of units free on both faces, bottom to top
+	FreeBlocks       []dctrack.URange `json:"free_blocks"`
+	LargestFreeBlock int              `json:"largest_free_block"`
+}
+
+// CabinetCapacityOf computes the capacity of cabinet from the items in it
+func CabinetCapacityOf(cabinet dctrack.Cabinet, items []dctrack.DCTrackItem, opts CapacityOptions) CabinetCapacity {
+	elevation := dctrack.NewCabinetElevation(cabinet, items)
+	capacity := CabinetCapacity{
+		CabinetUtilization: elevation.Utilization(),
+		CabinetID:          cabinet.ID,
+		Room:               cabinet.Room,
+		Row:                cabinet.Row,
+		PowerCapacity:      cabinet.PowerCapacity,
+		WeightCapacity:     opts.WeightCapacity,
+		FreeBlocks:         elevation.FreeRanges(dctrack.FaceBoth),
+	}
+	if capacity.PowerCapacity <= 0 {
+		capacity.PowerCapacity = opts.PowerCapacity
+	}
+	if capacity.FreeBlocks == nil {
+		capacity.FreeBlocks = []dctrack.URange{}
+	}
+	for _, block := range capacity.FreeBlocks {
+		capacity.LargestFreeBlock = max(capacity.LargestFreeBlock, block.Size())
+	}
+
+	for _, item := range items {
+		if power := dctrack.EffectivePower(item); power > 0 {
+			capacity.PowerUsed += power
+		}
+		if item.TiWeight > 0 {
+			capacity.WeightUsed += item.TiWeight
+		}
+	}
+	if capacity.PowerCapacity > 0 {
+		capacity.PowerHeadroom = capacity.PowerCapacity - capacity.PowerUsed
+	}
+	if capacity.WeightCapacity > 0 {
+		capacity.WeightHeadroom = capacity.WeightCapacity - capacity.WeightUsed
+	}
+	return capacity
+}
+
+// CabinetCapacities computes the capacity of every cabinet among items from
+// the items placed in it, sorted by location and cabinet name. Items are
+// matched to cabinets by location and cabinet name. A cabinet's "Weight
+// Capacity" custom field overrides opts.WeightCapacity.
+func CabinetCapacities(items []dctrack.DCTrackItem, opts CapacityOptions) []CabinetCapacity {
+	cabinetKey := func(location, name string) string {
+		return strings.ToLower(strings.TrimSpace(location)) + "\x00" + strings.ToLower(strings.TrimSpace(name))
+	}
+
+	var cabinets []dctrack.DCTrackItem
+	contents := make(map[string][]dctrack.DCTrackItem)
+	for _, item := range items {
+		if strings.EqualFold(item.ItemClass, "Cabinet") {
+			cabinets = append(cabinets, item)
+		} else if strings.TrimSpace(item.Cabinet) != "" {
+			key := cabinetKey(item.Location, item.Cabinet)
+			contents[key] = append(contents[key], item)
+		}
+	}
+
+	capacities := make([]CabinetCapacity, len(cabinets))
+	for i, item := range cabinets {
+		cabinetOpts := opts
+		if rating, ok := item.CustomField(weightCapacityField); ok && !rating.IsZero() {
+			if weight, err := rating.Float(); err == nil && weight > 0 {
+				cabinetOpts.WeightCapacity = weight
+			}
+		}
+		capacities[i] = CabinetCapacityOf(dctrack.CabinetFromItem(item), contents[cabinetKey(item.Location, item.Name)], cabinetOpts)
+	}
+	sort.SliceStable(capacities, func(i, j int) bool {
+		return lessKey([]string{capacities[i].Location, capacities[i].Cabinet},
+			[]string{capacities[j].Location, capacities[j].Cabinet})
+	})
+	return capacities
+}
+
+// PlacementRequest describes a device to be placed
+type PlacementRequest struct {
+	Height int     // Rack units; 0 is taken to be 1U
+	Power  float64 // Watts
+	Weight float64
+}
+
+// Fit is a cabinet a device fits in and where
+type Fit struct {
+	Location  string         `json:"location"`
+	Cabinet   string         `json:"cabinet"`
+	CabinetID string         `json:"cabinet_id"`
+	Range     dctrack.URange `json:"range"` // Units to mount the device in
+
+	// Headroom left after placing the device; 0 when the capacity is unknown
+	RUFree         int     `json:"ru_free"` // Units left in the chosen free block
+	PowerHeadroom  float64 `json:"power_headroom"`
+	WeightHeadroom float64 `json:"weight_headroom"`
+	PowerUnknown   bool    `json:"power_unknown"`
+	WeightUnknown  bool    `json:"weight_unknown"`
+
+	// Lower is a tighter fit
+	Score float64 `json:"score"`
+}
+
+// FindPlacement returns the cabinets the requested device fits in, tightest
+// fit first. In each cabinet the device takes the bottom of the smallest
+// free block it fits. The score adds up the fraction of the block, power
+// capacity and weight capacity the device would leave unused; an unknown
+// capacity is not checked and scores as fully unused, so cabinets with
+// known headroom rank first.
+func FindPlacement(capacities []CabinetCapacity, req PlacementRequest) []Fit {
+	height := max(req.Height, 1)
+
+	var fits []Fit
+	for _, capacity := range capacities {
+		block, ok := tightestBlock(capacity.FreeBlocks, height)
+		if !ok {
+			continue
+		}
+		if capacity.PowerCapacity > 0 && req.Power > capacity.PowerHeadroom {
+			continue
+		}
+		if capacity.WeightCapacity > 0 && req.Weight > capacity.WeightHeadroom {
+			continue
+		}
+
+		fit := Fit{
+			Location:  capacity.Location,
+			Cabinet:   capacity.Cabinet,
+			CabinetID: capacity.CabinetID,
+			Range:     dctrack.URange{Bottom: block.Bottom, Top: block.Bottom + height - 1},
+			RUFree:    block.Size() - height,
+		}
+		fit.Score = float64(fit.RUFree) / float64(block.Size())
+		if capacity.PowerCapacity > 0 {
+			fit.PowerHeadroom = capacity.PowerHeadroom - req.Power
+			fit.Score += fit.PowerHeadroom / capacity.PowerCapacity
+		} else {
+			fit.PowerUnknown = true
+			fit.Score++
+		}
+		if capacity.WeightCapacity > 0 {
+			fit.WeightHeadroom = capacity.WeightHeadroom - req.Weight
+			fit.Score += fit.WeightHeadroom / capacity.WeightCapacity
+		} else {
+			fit.WeightUnknown = true
+			fit.Score++
+		}
+		fits = append(fits, fit)
+	}
+
+	sort.SliceStable(fits, func(i, j int) bool {
+		if fits[i].Score != fits[j].Score {
+			return fits[i].Score < fits[j].Score
+		}
+		return lessKey([]string{fits[i].Location, fits[i].Cabinet}, []string{fits[j].Location, fits[j].Cabinet})
+	})
+	return fits
+}
+
+// tightestBlock returns the smallest block of at least height units,
+// the lowest one on ties
+func tightestBlock(blocks []dctrack.URange, height int) (dctrack.URange, bool) {
+	var best dctrack.URange
+	found := false
+	for _, block := range blocks {
+		if block.Size() >= height && (!found || block.Size() < best.Size()) {
+			best = block
+			found = true
+		}
+	}
+	return best, found
+}
+
+// capacityColumns are the columns cabinet capacity is computed from
+var capacityColumns = []string{"tiName", "tiClass", "cmbStatus", "cmbLocation", "cmbCabinet",
+	"cmbUPosition", "tiRUs", "tiMounting", "radioRailsUsed", "tiEffectivePower", "tiPowerCapacity",
+	"tiWeight", "tiRoomName", "cmbRowLabel", dctrack.CustomFieldColumn(weightCapacityField)}
+
+// CabinetCapacities fetches the cabinets and items matching params and
+// computes each cabinet's capacity. Unless params.Columns is set, only the
+// columns the computation reads are requested.
+func (c *Client) CabinetCapacities(ctx context.Context, params dctrack.ItemsParams, opts CapacityOptions) ([]CabinetCapacity, error) {
+	if len(params.Columns) == 0 {
+		params.Columns = capacityColumns
+	}
+
+	items, err := c.source.GetItemsWithParams(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching items for cabinet capacity: %w", err)
+	}
+	return CabinetCapacities(items, opts), nil
+}
+
+// FindPlacement fetches the cabinets at location and returns those the
+// requested device fits in, tightest fit first
+func (c *Client) FindPlacement(ctx context.Context, location string, req PlacementRequest, opts CapacityOptions) ([]Fit, error) {
+	capacities, err := c.CabinetCapacities(ctx, dctrack.ItemsParams{Location: location}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// DCTrack matches the location partially, so RDU2 also returns RDU20
+	var local []CabinetCapacity
+	for _, capacity := range capacities {
+		if location == "" || strings.EqualFold(capacity.Location, location) {
+			local = append(local, capacity)
+		}
+	}
+	return FindPlacement(local, req), nil
+}
@@ -0,0 +1,131 @@
+package analytics
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/Jethzabell/go-dctrack-client/dctrack"
+)
+
+var testCapacityOptions = CapacityOptions{WeightCapacity: 1000}
+
+func TestCabinetCapacitiesGolden(t *testing.T) {
+	checkGolden(t, "capacity", CabinetCapacities(loadItemsFile(t, "capacity_items.json"), testCapacityOptions))
+}
+
+func TestCabinetCapacities(t *testing.T) {
+	capacities := CabinetCapacities(loadItemsFile(t, "capacity_items.json"), testCapacityOptions)
+
+	var names []string
+	for _, capacity := range capacities {
+		names = append(names, capacity.Location+"/"+capacity.Cabinet)
+	}
+	expected := []string{"BOS2/CAB-A1", "RDU2/CAB-A1", "RDU2/CAB-A2", "RDU2/CAB-B1", "RDU2/CAB-B2"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Fatalf("Expected cabinets %v, got %v", expected, names)
+	}
+
+	// The front-mounted switch at U42 leaves the rear free but not both faces
+	a1 := capacities[1]
+	if a1.UsedRU != 5 || a1.AssetCount != 4 || a1.LargestFreeBlock != 37 ||
+		!reflect.DeepEqual(a1.FreeBlocks, []dctrack.URange{{Bottom: 5, Top: 41}}) {
+		t.Errorf("Expected 5 used units and a free block of U5-U41, got %+v", a1)
+	}
+	if a1.PowerUsed != 1500 || a1.PowerHeadroom != 3500 || a1.WeightCapacity != 500 || a1.WeightHeadroom != 422 {
+		t.Errorf("Expected 3500 W and 422 of weight headroom, got %+v", a1)
+	}
+
+	a2 := capacities[2]
+	if a2.PowerCapacity != 0 || a2.PowerHeadroom != 0 || a2.WeightCapacity != 1000 || a2.WeightHeadroom != 940 {
+		t.Errorf("Expected unknown power and the default weight capacity, got %+v", a2)
+	}
+}
+
+func TestCabinetCapacityNameplatePower(t *testing.T) {
+	cabinet := dctrack.Cabinet{Name: "CAB-A1", RUHeight: 42, PowerCapacity: 5000}
+	items := []dctrack.DCTrackItem{
+		{ID: "1", Position: "1", Height: 1, TiEffectivePower: 400},
+		{ID: "2", Position: "2", Height: 2, ModelSpec: &dctrack.ModelDefinition{NameplateWatts: 1100}},
+	}
+
+	// Items without a reported power count their model's nameplate power
+	capacity := CabinetCapacityOf(cabinet, items, CapacityOptions{})
+	if capacity.PowerUsed != 1500 || capacity.PowerHeadroom != 3500 {
+		t.Errorf("Expected 1500 W used and 3500 W headroom, got %+v", capacity)
+	}
+}
+
+func TestFindPlacement(t *testing.T) {
+	capacities := CabinetCapacities(loadItemsFile(t, "capacity_items.json"), testCapacityOptions)
+
+	tests := []struct {
+		name     string
+		req      PlacementRequest
+		cabinets []string
+		ranges   []dctrack.URange
+	}{
+		{
+			// CAB-B2 lacks the power, BOS2's CAB-A1 the space
+			name:     "2U 800W 25kg",
+			req:      PlacementRequest{Height: 2, Power: 800, Weight: 25},
+			cabinets: []string{"RDU2/CAB-B1", "RDU2/CAB-A1", "RDU2/CAB-A2"},
+			ranges:   []dctrack.URange{{Bottom: 7, Top: 8}, {Bottom: 5, Top: 6}, {Bottom: 1, Top: 2}},
+		},
+		{
+			name:     "too heavy for B1",
+			req:      PlacementRequest{Height: 2, Power: 100, Weight: 150},
+			cabinets: []string{"RDU2/CAB-B2", "RDU2/CAB-A1", "RDU2/CAB-A2"},
+			ranges:   []dctrack.URange{{Bottom: 1, Top: 2}, {Bottom: 5, Top: 6}, {Bottom: 1, Top: 2}},
+		},
+		{
+			name:     "1U by default",
+			req:      PlacementRequest{Power: 50},
+			cabinets: []string{"BOS2/CAB-A1", "RDU2/CAB-B1", "RDU2/CAB-B2", "RDU2/CAB-A1", "RDU2/CAB-A2"},
+			ranges: []dctrack.URange{{Bottom: 42, Top: 42}, {Bottom: 7, Top: 7}, {Bottom: 1, Top: 1},
+				{Bottom: 5, Top: 5}, {Bottom: 1, Top: 1}},
+		},
+		{
+			name: "nothing fits",
+			req:  PlacementRequest{Height: 40},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cabinets []string
+			var ranges []dctrack.URange
+			for _, fit := range FindPlacement(capacities, tt.req) {
+				cabinets = append(cabinets, fit.Location+"/"+fit.Cabinet)
+				ranges = append(ranges, fit.Range)
+			}
+			if !reflect.DeepEqual(cabinets, tt.cabinets) || !reflect.DeepEqual(ranges, tt.ranges) {
+				t.Errorf("Expected %v at %v, got %v at %v", tt.cabinets, tt.ranges, cabinets, ranges)
+			}
+		})
+	}
+}
+
+func TestClientFindPlacement(t *testing.T) {
+	source := &fakeSource{items: loadItemsFile(t, "capacity_items.json"), projected: true}
+	client := NewClient(source)
+	ctx := context.Background()
+
+	fits, err := client.FindPlacement(ctx, "RDU2", PlacementRequest{Height: 2, Power: 800, Weight: 25}, testCapacityOptions)
+	if err != nil {
+		t.Fatalf("FindPlacement failed: %v", err)
+	}
+	// The fake source returns BOS2 too, as a partial location match may
+	if len(fits) != 3 || fits[0].Cabinet != "CAB-B1" || fits[0].CabinetID != "300" || fits[0].PowerHeadroom != 100 {
+		t.Errorf("Expected CAB-B1 to fit best with 100 W to spare, got %+v", fits)
+	}
+	if source.params.Location != "RDU2" {
+		t.Errorf("Expected RDU2 items, got %+v", source.params)
+	}
+
+	source.err = dctrack.ErrNotFound
+	if _, err := client.CabinetCapacities(ctx, dctrack.ItemsParams{}, CapacityOptions{}); !errors.Is(err, dctrack.ErrNotFound) {
+		t.Errorf("Expected the fetch error, got %v", err)
+	}
+}
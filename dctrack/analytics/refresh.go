@@ -0,0 +1,197 @@
+package analytics
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Jethzabell/go-dctrack-client/dctrack"
+)
+
+// warrantyField is the custom field holding an item's warranty expiry
+const warrantyField = "Warranty Expiration Date"
+
+// RefreshPolicy decides when an item is due for refresh: the earliest of
+// its planned decommission date, its installation date plus the maximum
+// age of its class and, when enabled, its warranty expiry and contract end
+type RefreshPolicy struct {
+	// Maximum age in years by item class, compared case-insensitively
+	MaxAgeByClass map[string]int
+	// Maximum age in years of classes not listed; 0 for no age limit
+	DefaultMaxAge int
+
+	// Also refresh when the warranty expires or the support contract ends
+	RefreshAtWarrantyEnd bool
+	RefreshAtContractEnd bool
+
+	// First year of the plan (default: the current year); items due
+	// earlier are counted as overdue in it
+	From time.Time
+	// Number of years planned; items due later are left out of
+	// UpcomingRefreshes. 0 plans every year with items due.
+	Years int
+}
+
+// maxAge returns the maximum age in years of an item class
+func (p RefreshPolicy) maxAge(class string) int {
+	for name, age := range p.MaxAgeByClass {
+		if strings.EqualFold(name, class) {
+			return age
+		}
+	}
+	return p.DefaultMaxAge
+}
+
+// RefreshDue returns when an item is due for refresh under the policy, or
+// false when none of the dates the policy uses are known
+func (p RefreshPolicy) RefreshDue(item dctrack.DCTrackItem) (time.Time, bool) {
+	var candidates []time.Time
+	if decomm := item.TiPlannedDecommDate; decomm != nil {
+		candidates = append(candidates, *decomm)
+	}
+	if installed := installDate(item); installed != nil {
+		if age := p.maxAge(item.ItemClass); age > 0 {
+			candidates = append(candidates, installed.AddDate(age, 0, 0))
+		}
+	}
+	if p.RefreshAtWarrantyEnd {
+		if expiry := warrantyEnd(item); expiry != nil {
+			candidates = append(candidates, *expiry)
+		}
+	}
+	if p.RefreshAtContractEnd && item.ContractEndDate != nil {
+		candidates = append(candidates, *item.ContractEndDate)
+	}
+
+	if len(candidates) == 0 {
+		return time.Time{}, false
+	}
+	due := candidates[0]
+	for _, candidate := range candidates[1:] {
+		if candidate.Before(due) {
+			due = candidate
+		}
+	}
+	return due, true
+}
+
+// PlanRefresh buckets items by the year of each of their lifecycle dates,
+// and schedules each one for refresh under policy, totalling its purchase
+// price and contract amount in the year it is due
+func PlanRefresh(items []dctrack.DCTrackItem, policy RefreshPolicy) dctrack.RefreshPlan {
+	from := policy.From
+	if from.IsZero() {
+		from = time.Now()
+	}
+	firstYear := from.Year()
+
+	plan := dctrack.RefreshPlan{
+		ByContractEndYear: make(map[string]int),
+		ByInstallYear:     make(map[string]int),
+		ByDecommYear:      make(map[string]int),
+		ByWarrantyEndYear: make(map[string]int),
+	}
+
+	years := make(map[int]*dctrack.UpcomingRefresh)
+	locations := make(map[int]map[string]bool)
+	for _, item := range items {
+		countYear(plan.ByContractEndYear, item.ContractEndDate)
+		countYear(plan.ByInstallYear, installDate(item))
+		countYear(plan.ByDecommYear, item.TiPlannedDecommDate)
+		countYear(plan.ByWarrantyEndYear, warrantyEnd(item))
+
+		due, ok := policy.RefreshDue(item)
+		if !ok {
+			plan.Unscheduled++
+			continue
+		}
+		plan.TotalAssetsTracked++
+
+		year := due.Year()
+		overdue := year < firstYear
+		if overdue {
+			year = firstYear
+		}
+		if policy.Years > 0 && year >= firstYear+policy.Years {
+			continue
+		}
+
+		refresh, ok := years[year]
+		if !ok {
+			refresh = &dctrack.UpcomingRefresh{Year: strconv.Itoa(year)}
+			years[year] = refresh
+			locations[year] = make(map[string]bool)
+		}
+		refresh.AssetCount++
+		if overdue {
+			refresh.OverdueCount++
+		}
+		if item.TiPurchasePrice > 0 {
+			refresh.TotalValue += item.TiPurchasePrice
+		}
+		if item.TiContractAmount > 0 {
+			refresh.ContractValue += item.TiContractAmount
+		}
+		if location := strings.TrimSpace(item.Location); location != "" && !locations[year][location] {
+			locations[year][location] = true
+			refresh.Locations = append(refresh.Locations, location)
+		}
+	}
+
+	for _, refresh := range years {
+		sort.Strings(refresh.Locations)
+		plan.UpcomingRefreshes = append(plan.UpcomingRefreshes, *refresh)
+	}
+	sort.Slice(plan.UpcomingRefreshes, func(i, j int) bool {
+		return plan.UpcomingRefreshes[i].Year < plan.UpcomingRefreshes[j].Year
+	})
+	return plan
+}
+
+// PlanRefresh fetches the items matching params and plans their refresh.
+// Unless params.Columns is set, only the columns the plan reads are
+// requested.
+func (c *Client) PlanRefresh(ctx context.Context, params dctrack.ItemsParams, policy RefreshPolicy) (*dctrack.RefreshPlan, error) {
+	if len(params.Columns) == 0 {
+		params.Columns = []string{"tiClass", "cmbLocation", "installationDate", "contractEndDate",
+			"tiPlannedDecommDate", dctrack.CustomFieldColumn(warrantyField), "tiPurchasePrice", "tiContractAmount"}
+	}
+
+	items, err := c.source.GetItemsWithParams(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching items to plan refresh: %w", err)
+	}
+	plan := PlanRefresh(items, policy)
+	return &plan, nil
+}
+
+// countYear counts a date under its year
+func countYear(counts map[string]int, date *time.Time) {
+	if date != nil && !date.IsZero() {
+		counts[strconv.Itoa(date.Year())]++
+	}
+}
+
+// installDate returns when an item was installed, if known
+func installDate(item dctrack.DCTrackItem) *time.Time {
+	if item.InstallationDate != nil {
+		return item.InstallationDate
+	}
+	return item.InstallDate
+}
+
+// warrantyEnd returns when an item's warranty expires, if known
+func warrantyEnd(item dctrack.DCTrackItem) *time.Time {
+	if item.TiCustomFieldWarrantyExpirationDate != nil {
+		return item.TiCustomFieldWarrantyExpirationDate
+	}
+	if value, ok := item.CustomField(warrantyField); ok && !value.IsZero() {
+		if expiry, err := value.Time(); err == nil {
+			return &expiry
+		}
+	}
+	return nil
+}
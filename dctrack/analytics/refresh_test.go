@@ -0,0 +1,76 @@
+package analytics
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Jethzabell/go-dctrack-client/dctrack"
+)
+
+func loadRefreshItems(t *testing.T) []dctrack.DCTrackItem {
+	t.Helper()
+	return loadItemsFile(t, "refresh_items.json")
+}
+
+var testRefreshPolicy = RefreshPolicy{
+	MaxAgeByClass:        map[string]int{"Device": 5, "network": 7},
+	RefreshAtWarrantyEnd: true,
+	From:                 time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+	Years:                4,
+}
+
+func TestPlanRefreshGolden(t *testing.T) {
+	checkGolden(t, "refresh", PlanRefresh(loadRefreshItems(t), testRefreshPolicy))
+}
+
+func TestRefreshDue(t *testing.T) {
+	items := loadRefreshItems(t)
+	date := func(s string) time.Time {
+		d, _ := time.Parse("2006-01-02", s)
+		return d
+	}
+
+	tests := []struct {
+		name   string
+		policy RefreshPolicy
+		item   int
+		due    time.Time
+		ok     bool
+	}{
+		{"age of class", testRefreshPolicy, 0, date("2023-05-01"), true},
+		{"warranty before age", testRefreshPolicy, 1, date("2026-06-01"), true},
+		{"warranty ignored", RefreshPolicy{DefaultMaxAge: 6}, 1, date("2027-06-01"), true},
+		{"decommission before age", testRefreshPolicy, 2, date("2025-09-30"), true},
+		{"no age for class", testRefreshPolicy, 3, time.Time{}, false},
+		{"default age", RefreshPolicy{DefaultMaxAge: 20}, 3, date("2035-01-01"), true},
+		{"warranty custom field", testRefreshPolicy, 5, date("2027-12-31"), true},
+		{"contract end ignored", testRefreshPolicy, 6, date("2027-03-01"), true},
+		{"contract end", RefreshPolicy{MaxAgeByClass: map[string]int{"device": 5}, RefreshAtContractEnd: true}, 6, date("2026-03-01"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			due, ok := tt.policy.RefreshDue(items[tt.item])
+			if ok != tt.ok || !due.Equal(tt.due) {
+				t.Errorf("Expected %v (%v), got %v (%v)", tt.due, tt.ok, due, ok)
+			}
+		})
+	}
+}
+
+func TestClientPlanRefresh(t *testing.T) {
+	source := &fakeSource{items: loadRefreshItems(t)}
+
+	plan, err := NewClient(source).PlanRefresh(context.Background(), dctrack.ByLocation("RDU2"), testRefreshPolicy)
+	if err != nil {
+		t.Fatalf("PlanRefresh failed: %v", err)
+	}
+	if !reflect.DeepEqual(*plan, PlanRefresh(source.items, testRefreshPolicy)) {
+		t.Errorf("Expected the same plan as PlanRefresh, got %+v", plan)
+	}
+	if len(source.params.Columns) != 8 || source.params.Columns[5] != "tiCustomField_Warranty Expiration Date" {
+		t.Errorf("Expected the refresh columns, got %v", source.params.Columns)
+	}
+}
@@ -0,0 +1,300 @@
+package analytics
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Jethzabell/go-dctrack-client/dctrack"
+)
+
+// makeSuffixes are corporate suffixes dropped from make names
+var makeSuffixes = []string{"inc", "incorporated", "corp", "corporation", "co", "company",
+	"ltd", "limited", "llc", "gmbh", "ag", "sa", "plc"}
+
+// makeAliases maps normalized make names, in lower case, to their vendor
+var makeAliases = map[string]string{
+	"dell":                            "Dell",
+	"dell emc":                        "Dell",
+	"dell technologies":               "Dell",
+	"emc":                             "Dell",
+	"hp":                              "HP",
+	"hewlett packard":                 "HP",
+	"hewlett-packard":                 "HP",
+	"hpe":                             "HPE",
+	"hewlett packard enterprise":      "HPE",
+	"hewlett-packard enterprise":      "HPE",
+	"cisco":                           "Cisco",
+	"cisco systems":                   "Cisco",
+	"ibm":                             "IBM",
+	"international business machines": "IBM",
+	"lenovo":                          "Lenovo",
+	"juniper":                         "Juniper",
+	"juniper networks":                "Juniper",
+	"arista":                          "Arista",
+	"arista networks":                 "Arista",
+	"netapp":                          "NetApp",
+	"supermicro":                      "Supermicro",
+	"super micro":                     "Supermicro",
+	"super micro computer":            "Supermicro",
+	"apc":                             "APC",
+	"apc by schneider electric":       "APC",
+	"raritan":                         "Raritan",
+	"vertiv":                          "Vertiv",
+	"liebert":                         "Vertiv",
+}
+
+// NormalizeMake returns the vendor a make name belongs to: corporate
+// suffixes and punctuation around them are dropped, and known spellings
+// such as "Dell Inc.", "DELL" and "Dell EMC" map to one name. Unknown makes
+// are returned trimmed, with their spelling kept.
+func NormalizeMake(name string) string {
+	return normalizeMake(name, nil)
+}
+
+// normalizeMake normalizes a make, consulting aliases before the built-in
+// ones
+func normalizeMake(raw string, aliases map[string]string) string {
+	words := strings.Fields(raw)
+	for len(words) > 1 {
+		last := strings.ToLower(strings.Trim(words[len(words)-1], ".,"))
+		if !isMakeSuffix(last) {
+			break
+		}
+		words = words[:len(words)-1]
+	}
+	name := strings.TrimRight(strings.Join(words, " "), ".,")
+
+	key := strings.ToLower(name)
+	for alias, vendor := range aliases {
+		if strings.EqualFold(alias, key) || strings.EqualFold(alias, strings.TrimSpace(raw)) {
+			return vendor
+		}
+	}
+	if vendor, ok := makeAliases[key]; ok {
+		return vendor
+	}
+	return name
+}
+
+func isMakeSuffix(word string) bool {
+	for _, suffix := range makeSuffixes {
+		if word == suffix {
+			return true
+		}
+	}
+	return false
+}
+
+// VendorOptions controls a vendor analysis
+type VendorOptions struct {
+	// Only count items in this location or of this class, compared
+	// case-insensitively
+	Location  string
+	ItemClass string
+
+	// Power figure to total: dctrack.PowerOriginal (the default),
+	// dctrack.PowerConsumption or dctrack.PowerPotential
+	PowerType string
+
+	// Makes with a smaller percentage of the assets are long tail
+	// (default: 2)
+	LongTailPercent float64
+
+	// Additional make spellings and the vendor they belong to, checked
+	// before the built-in ones
+	Aliases map[string]string
+}
+
+// VendorShare is the share of one make, or make and model, of the assets,
+// power and purchase value
+type VendorShare struct {
+	dctrack.VendorDistribution
+
+	Model string `json:"model,omitempty"`
+
+	PowerKW         float64 `json:"power_kw"`
+	PowerPercentage float64 `json:"power_percentage"`
+	PurchaseValue   float64 `json:"purchase_value"`
+	CostPercentage  float64 `json:"cost_percentage"`
+
+	// Make names as they appear on the items
+	Spellings []string `json:"spellings"`
+	LongTail  bool     `json:"long_tail"`
+}
+
+// VendorReport is the vendor mix of an inventory
+type VendorReport struct {
+	TotalAssets   int     `json:"total_assets"`
+	TotalPowerKW  float64 `json:"total_power_kw"`
+	PurchaseValue float64 `json:"purchase_value"`
+
+	// By make and by make and model, largest first, with items without a
+	// make last
+	Makes  []VendorShare `json:"makes"`
+	Models []VendorShare `json:"models"`
+
+	// Makes below the long-tail threshold
+	LongTail []string `json:"long_tail"`
+}
+
+// shareTotals accumulates one make or model
+type shareTotals struct {
+	share VendorShare
+	power float64 // Watts
+	seen  map[string]bool
+}
+
+func (s *shareTotals) add(item dctrack.DCTrackItem, power float64) {
+	s.share.AssetCount++
+	if power > 0 {
+		s.power += power
+	}
+	if item.TiPurchasePrice > 0 {
+		s.share.PurchaseValue += item.TiPurchasePrice
+	}
+	if spelling := strings.TrimSpace(item.Make); spelling != "" && !s.seen[spelling] {
+		s.seen[spelling] = true
+		s.share.Spellings = append(s.share.Spellings, spelling)
+	}
+}
+
+// VendorMix totals the assets, power and purchase value of items per
+// normalized make and per make and model. Models are compared
+// case-insensitively and shown as first seen.
+func VendorMix(items []dctrack.DCTrackItem, opts VendorOptions) (VendorReport, error) {
+	if _, err := dctrack.PowerOf(dctrack.DCTrackItem{}, opts.PowerType); err != nil {
+		return VendorReport{}, err
+	}
+	threshold := opts.LongTailPercent
+	if threshold == 0 {
+		threshold = 2
+	}
+
+	report := VendorReport{LongTail: []string{}}
+	var power float64
+	makes := make(map[string]*shareTotals)
+	models := make(map[string]*shareTotals)
+	var makeOrder, modelOrder []*shareTotals
+	group := func(groups map[string]*shareTotals, order *[]*shareTotals, key, vendor, model string) *shareTotals {
+		totals, ok := groups[key]
+		if !ok {
+			totals = &shareTotals{seen: make(map[string]bool)}
+			totals.share.Make = vendor
+			totals.share.Model = model
+			totals.share.Spellings = []string{}
+			groups[key] = totals
+			*order = append(*order, totals)
+		}
+		return totals
+	}
+
+	for _, item := range items {
+		if opts.Location != "" && !strings.EqualFold(strings.TrimSpace(item.Location), opts.Location) {
+			continue
+		}
+		if opts.ItemClass != "" && !strings.EqualFold(strings.TrimSpace(item.ItemClass), opts.ItemClass) {
+			continue
+		}
+
+		vendor := normalizeMake(item.Make, opts.Aliases)
+		model := strings.Join(strings.Fields(item.Model), " ")
+		watts, _ := dctrack.PowerOf(item, opts.PowerType)
+
+		report.TotalAssets++
+		if watts > 0 {
+			power += watts
+		}
+		if item.TiPurchasePrice > 0 {
+			report.PurchaseValue += item.TiPurchasePrice
+		}
+
+		makeKey := strings.ToLower(vendor)
+		group(makes, &makeOrder, makeKey, vendor, "").add(item, watts)
+		group(models, &modelOrder, makeKey+"\x00"+strings.ToLower(model), vendor, model).add(item, watts)
+	}
+	report.TotalPowerKW = power / 1000
+
+	report.Makes = finishShares(makeOrder, report, power)
+	report.Models = finishShares(modelOrder, report, power)
+	for i := range report.Makes {
+		share := &report.Makes[i]
+		if share.Make != "" && share.Percentage < threshold {
+			share.LongTail = true
+			report.LongTail = append(report.LongTail, share.Make)
+		}
+	}
+	for i := range report.Models {
+		for _, vendor := range report.LongTail {
+			if report.Models[i].Make == vendor {
+				report.Models[i].LongTail = true
+			}
+		}
+	}
+	return report, nil
+}
+
+// finishShares derives the percentages of each share and sorts them by
+// asset count, then power, then name, with items without a make last
+func finishShares(order []*shareTotals, report VendorReport, power float64) []VendorShare {
+	shares := make([]VendorShare, len(order))
+	for i, totals := range order {
+		share := totals.share
+		share.PowerKW = totals.power / 1000
+		if report.TotalAssets > 0 {
+			share.Percentage = 100 * float64(share.AssetCount) / float64(report.TotalAssets)
+		}
+		if power > 0 {
+			share.PowerPercentage = 100 * totals.power / power
+		}
+		if report.PurchaseValue > 0 {
+			share.CostPercentage = 100 * share.PurchaseValue / report.PurchaseValue
+		}
+		shares[i] = share
+	}
+	sort.SliceStable(shares, func(i, j int) bool {
+		a, b := shares[i], shares[j]
+		if (a.Make == "") != (b.Make == "") {
+			return b.Make == ""
+		}
+		if a.AssetCount != b.AssetCount {
+			return a.AssetCount > b.AssetCount
+		}
+		if a.PowerKW != b.PowerKW {
+			return a.PowerKW > b.PowerKW
+		}
+		return lessKey([]string{a.Make, a.Model}, []string{b.Make, b.Model})
+	})
+	return shares
+}
+
+// VendorMix fetches the items matching params and totals them per make and
+// model. opts.Location and opts.ItemClass are also sent to DCTrack when
+// params does not filter on them. Unless params.Columns is set, only the
+// columns the analysis reads are requested.
+func (c *Client) VendorMix(ctx context.Context, params dctrack.ItemsParams, opts VendorOptions) (*VendorReport, error) {
+	column := dctrack.PowerColumn(opts.PowerType)
+	if column == "" {
+		return nil, fmt.Errorf("unknown power type %q", opts.PowerType)
+	}
+	if params.Location == "" {
+		params.Location = opts.Location
+	}
+	if params.ItemClass == "" {
+		params.ItemClass = opts.ItemClass
+	}
+	if len(params.Columns) == 0 {
+		params.Columns = []string{"cmbMake", "cmbModel", "cmbLocation", "tiClass", column, "tiPurchasePrice"}
+	}
+
+	items, err := c.source.GetItemsWithParams(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching items for vendor mix: %w", err)
+	}
+	report, err := VendorMix(items, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
@@ -0,0 +1,111 @@
+package analytics
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/Jethzabell/go-dctrack-client/dctrack"
+)
+
+func TestNormalizeMake(t *testing.T) {
+	tests := []struct {
+		make     string
+		expected string
+	}{
+		{"Dell Inc.", "Dell"},
+		{"DELL", "Dell"},
+		{"Dell EMC", "Dell"},
+		{"  dell   technologies ", "Dell"},
+		{"Hewlett-Packard", "HP"},
+		{"Hewlett Packard Enterprise Co.", "HPE"},
+		{"Cisco Systems, Inc.", "Cisco"},
+		{"Super Micro Computer, Inc.", "Supermicro"},
+		{"Acme Widgets LLC", "Acme Widgets"},
+		{"Inc", "Inc"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeMake(tt.make); got != tt.expected {
+			t.Errorf("Expected %q for %q, got %q", tt.expected, tt.make, got)
+		}
+	}
+}
+
+func TestVendorMixGolden(t *testing.T) {
+	items := loadItemsFile(t, "vendor_items.json")
+
+	tests := []struct {
+		name string
+		opts VendorOptions
+	}{
+		{"vendors", VendorOptions{LongTailPercent: 15}},
+		{"vendors_rdu2_devices", VendorOptions{Location: "rdu2", ItemClass: "Device"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := VendorMix(items, tt.opts)
+			if err != nil {
+				t.Fatalf("VendorMix failed: %v", err)
+			}
+			checkGolden(t, tt.name, report)
+		})
+	}
+}
+
+func TestVendorMix(t *testing.T) {
+	items := loadItemsFile(t, "vendor_items.json")
+
+	report, err := VendorMix(items, VendorOptions{LongTailPercent: 15})
+	if err != nil {
+		t.Fatalf("VendorMix failed: %v", err)
+	}
+	if !reflect.DeepEqual(report.LongTail, []string{"Acme Widgets", "Raritan"}) {
+		t.Errorf("Expected Acme Widgets and Raritan in the long tail, got %v", report.LongTail)
+	}
+	dell := report.Makes[0]
+	if dell.Make != "Dell" || dell.AssetCount != 3 || dell.Percentage != 30 ||
+		!reflect.DeepEqual(dell.Spellings, []string{"Dell Inc.", "DELL", "Dell EMC"}) {
+		t.Errorf("Expected three Dell assets under three spellings, got %+v", dell)
+	}
+	if last := report.Makes[len(report.Makes)-1]; last.Make != "" || last.AssetCount != 1 {
+		t.Errorf("Expected the item without a make last, got %+v", last)
+	}
+	if r750 := report.Models[0]; r750.Model != "PowerEdge R750" || r750.AssetCount != 2 {
+		t.Errorf("Expected two PowerEdge R750, got %+v", r750)
+	}
+
+	report, _ = VendorMix(items, VendorOptions{Aliases: map[string]string{"Acme Widgets LLC": "Dell"}})
+	if report.Makes[0].AssetCount != 4 {
+		t.Errorf("Expected the alias to add Acme to Dell, got %+v", report.Makes[0])
+	}
+
+	if _, err := VendorMix(items, VendorOptions{PowerType: "peak"}); err == nil {
+		t.Errorf("Expected error for an unknown power type")
+	}
+}
+
+func TestClientVendorMix(t *testing.T) {
+	source := &fakeSource{items: loadItemsFile(t, "vendor_items.json")}
+	client := NewClient(source)
+	ctx := context.Background()
+
+	report, err := client.VendorMix(ctx, dctrack.InstalledOnly(), VendorOptions{Location: "BOS2", PowerType: dctrack.PowerConsumption})
+	if err != nil {
+		t.Fatalf("VendorMix failed: %v", err)
+	}
+	if report.TotalAssets != 3 {
+		t.Errorf("Expected 3 assets in BOS2, got %d", report.TotalAssets)
+	}
+	expected := []string{"cmbMake", "cmbModel", "cmbLocation", "tiClass", "tiEffectivePower", "tiPurchasePrice"}
+	if source.params.Location != "BOS2" || source.params.Status != "Installed" || !reflect.DeepEqual(source.params.Columns, expected) {
+		t.Errorf("Expected installed BOS2 items with columns %v, got %+v", expected, source.params)
+	}
+
+	source.err = dctrack.ErrNotFound
+	if _, err := client.VendorMix(ctx, dctrack.ItemsParams{}, VendorOptions{}); !errors.Is(err, dctrack.ErrNotFound) {
+		t.Errorf("Expected the fetch error, got %v", err)
+	}
+}
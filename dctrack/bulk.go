@@ -0,0 +1,361 @@
+package dctrack
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// BulkAction is what BulkUpsertItems did, or would do in a dry run, with a row
+type BulkAction string
+
+const (
+	BulkCreated BulkAction = "created"
+	BulkUpdated BulkAction = "updated"
+	BulkSkipped BulkAction = "skipped"
+	BulkFailed  BulkAction = "failed"
+)
+
+// BulkOptions controls BulkUpsertItems
+type BulkOptions struct {
+	BatchSize   int  // Rows processed before the next batch starts (default: 50)
+	Concurrency int  // Rows written in parallel within a batch (default: Config.Concurrency)
+	DryRun      bool // Match and diff rows without writing anything
+
+	// Existing, when set, is the inventory rows are matched against instead
+	// of searching DCTrack for each row's serial number or asset tag
+	Existing []DCTrackItem
+
+	// Progress is called after each batch with the rows processed so far
+	Progress func(done, total int)
+}
+
+// FieldChange is one field an update would change
+type FieldChange struct {
+	Field string // DCTrack field name
+	Old   string
+	New   string
+}
+
+// BulkRowResult reports the outcome of one input row
+type BulkRowResult struct {
+	Row     int // Index in the input slice
+	Action  BulkAction
+	ID      string        // Item created, updated or matched
+	Reason  string        // Why the row was skipped or failed
+	Err     error         // Underlying error of a failed row
+	Changes []FieldChange // Fields written, or that would be written in a dry run
+}
+
+// BulkReport is the per-row outcome of BulkUpsertItems
+type BulkReport struct {
+	DryRun  bool
+	Rows    []BulkRowResult
+	Created int
+	Updated int
+	Skipped int
+	Failed  int
+}
+
+// BulkUpsertItems creates or updates an item for each input row. Rows are
+// matched to existing items by serial number, then asset tag: a match is
+// updated with the fields that differ, no match is created, and a row
+// without either key is skipped. Rows are processed in batches with
+// bounded concurrency, and one failed row does not stop the others. The
+// error is only set when the import as a whole could not run.
+func (c *Client) BulkUpsertItems(ctx context.Context, inputs []ItemInput, opts BulkOptions) (*BulkReport, error) {
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = 50
+	}
+	workers := opts.Concurrency
+	if workers <= 0 {
+		workers = max(c.config.Concurrency, 1)
+	}
+
+	report := &BulkReport{
+		DryRun: opts.DryRun || c.config.DryRun,
+		Rows:   make([]BulkRowResult, len(inputs)),
+	}
+
+	var index *itemIndex
+	if opts.Existing != nil {
+		index = newItemIndex(opts.Existing)
+	}
+	duplicates := findDuplicateKeys(inputs)
+
+	c.logger.Info("Starting DCTrack bulk upsert",
+		zap.Int("rows", len(inputs)),
+		zap.Int("batch_size", batchSize),
+		zap.Int("workers", workers),
+		zap.Bool("dry_run", report.DryRun))
+
+	for start := 0; start < len(inputs); start += batchSize {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		end := min(start+batchSize, len(inputs))
+
+		var wg sync.WaitGroup
+		rows := make(chan int)
+		for w := 0; w < min(workers, end-start); w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for row := range rows {
+					result := BulkRowResult{Row: row}
+					if first, ok := duplicates[row]; ok {
+						result.Action = BulkFailed
+						result.Reason = fmt.Sprintf("duplicate of row %d", first)
+					} else {
+						result = c.upsertRow(ctx, row, inputs[row], index, report.DryRun)
+					}
+					report.Rows[row] = result
+				}
+			}()
+		}
+		for row := start; row < end; row++ {
+			rows <- row
+		}
+		close(rows)
+		wg.Wait()
+
+		if opts.Progress != nil {
+			opts.Progress(end, len(inputs))
+		}
+	}
+
+	for _, row := range report.Rows {
+		switch row.Action {
+		case BulkCreated:
+			report.Created++
+		case BulkUpdated:
+			report.Updated++
+		case BulkSkipped:
+			report.Skipped++
+		case BulkFailed:
+			report.Failed++
+		}
+	}
+
+	c.logger.Info("Finished DCTrack bulk upsert",
+		zap.Int("created", report.Created),
+		zap.Int("updated", report.Updated),
+		zap.Int("skipped", report.Skipped),
+		zap.Int("failed", report.Failed),
+		zap.Bool("dry_run", report.DryRun))
+
+	return report, nil
+}
+
+// upsertRow matches one row and creates or updates its item
+func (c *Client) upsertRow(ctx context.Context, row int, input ItemInput, index *itemIndex, dryRun bool) BulkRowResult {
+	result := BulkRowResult{Row: row}
+	fail := func(reason string, err error) BulkRowResult {
+		result.Action = BulkFailed
+		result.Reason = reason
+		result.Err = err
+		return result
+	}
+
+	serial, tag := inputKeys(input)
+	if serial == "" && tag == "" {
+		result.Action = BulkSkipped
+		result.Reason = "no serial number or asset tag to match on"
+		return result
+	}
+
+	existing, err := c.matchItem(ctx, serial, tag, index)
+	if err != nil {
+		return fail("matching existing items failed", err)
+	}
+
+	if existing == nil {
+		if err := input.validate(true); err != nil {
+			return fail("invalid item", err)
+		}
+		result.Action = BulkCreated
+		result.Changes = diffFields(nil, input.Fields())
+		if dryRun {
+			return result
+		}
+
+		item, err := c.CreateItem(ctx, input)
+		if err != nil {
+			return fail("create failed", err)
+		}
+		result.ID = item.ID
+		return result
+	}
+
+	result.ID = existing.ID
+	if err := input.validate(false); err != nil {
+		return fail("invalid item", err)
+	}
+	result.Changes = diffFields(ItemInputFromItem(*existing).Fields(), input.Fields())
+	if len(result.Changes) == 0 {
+		result.Action = BulkSkipped
+		result.Reason = "unchanged"
+		return result
+	}
+
+	result.Action = BulkUpdated
+	if dryRun {
+		return result
+	}
+
+	changed := make(map[string]interface{}, len(result.Changes))
+	fields := input.Fields()
+	for _, change := range result.Changes {
+		changed[change.Field] = fields[change.Field]
+	}
+	if _, err := c.UpdateItem(ctx, existing.ID, ItemInput{Extra: changed}); err != nil {
+		return fail("update failed", err)
+	}
+	return result
+}
+
+// matchKeyColumns are the columns a quicksearch for a row's keys selects
+var matchKeyColumns = []string{"tiSerialNumber", "tiAssetTag"}
+
+// matchItem finds the item with the row's serial number, or failing that
+// its asset tag. It returns nil when nothing matches and an error when the
+// match is ambiguous. An item found by quicksearch is read again from the
+// items endpoint, so the row is diffed against every field.
+func (c *Client) matchItem(ctx context.Context, serial, tag string, index *itemIndex) (*DCTrackItem, error) {
+	lookups := []struct {
+		key   string
+		value string
+	}{
+		{"serial number", serial},
+		{"asset tag", tag},
+	}
+
+	for _, lookup := range lookups {
+		if lookup.value == "" {
+			continue
+		}
+
+		var candidates []DCTrackItem
+		if index != nil {
+			candidates = index.lookup(lookup.key, lookup.value)
+		} else {
+			found, err := c.GetItemsWithParams(ctx, ItemsParams{
+				SearchText: lookup.value,
+				PageSize:   c.config.PageSize,
+				Columns:    matchKeyColumns,
+			})
+			if err != nil {
+				return nil, err
+			}
+			candidates = newItemIndex(found).lookup(lookup.key, lookup.value)
+		}
+
+		switch len(candidates) {
+		case 0:
+			continue
+		case 1:
+			if index != nil {
+				return &candidates[0], nil
+			}
+			return c.GetItemByID(ctx, candidates[0].ID)
+		default:
+			return nil, fmt.Errorf("%d items share %s %s", len(candidates), lookup.key, lookup.value)
+		}
+	}
+	return nil, nil
+}
+
+// itemIndex finds items by serial number or asset tag
+type itemIndex struct {
+	bySerial map[string][]DCTrackItem
+	byTag    map[string][]DCTrackItem
+}
+
+func newItemIndex(items []DCTrackItem) *itemIndex {
+	index := &itemIndex{
+		bySerial: make(map[string][]DCTrackItem),
+		byTag:    make(map[string][]DCTrackItem),
+	}
+	for _, item := range items {
+		serial := item.TiSerialNumber
+		if serial == "" {
+			serial = item.SerialNumber
+		}
+		if serial != "" {
+			index.bySerial[serial] = append(index.bySerial[serial], item)
+		}
+		if item.TiAssetTag != "" {
+			index.byTag[item.TiAssetTag] = append(index.byTag[item.TiAssetTag], item)
+		}
+	}
+	return index
+}
+
+func (idx *itemIndex) lookup(key, value string) []DCTrackItem {
+	if key == "serial number" {
+		return idx.bySerial[value]
+	}
+	return idx.byTag[value]
+}
+
+// inputKeys returns the row's serial number and asset tag, if set
+func inputKeys(input ItemInput) (serial, tag string) {
+	if input.SerialNumber != nil {
+		serial = *input.SerialNumber
+	}
+	if input.AssetTag != nil {
+		tag = *input.AssetTag
+	}
+	return serial, tag
+}
+
+// findDuplicateKeys maps each row repeating an earlier row's serial number
+// or asset tag to that earlier row, so one device is not written twice
+func findDuplicateKeys(inputs []ItemInput) map[int]int {
+	duplicates := make(map[int]int)
+	seenSerial := make(map[string]int)
+	seenTag := make(map[string]int)
+
+	for row, input := range inputs {
+		serial, tag := inputKeys(input)
+		if first, ok := seenSerial[serial]; ok && serial != "" {
+			duplicates[row] = first
+			continue
+		}
+		if first, ok := seenTag[tag]; ok && tag != "" {
+			duplicates[row] = first
+			continue
+		}
+		if serial != "" {
+			seenSerial[serial] = row
+		}
+		if tag != "" {
+			seenTag[tag] = row
+		}
+	}
+	return duplicates
+}
+
+// diffFields lists the fields of updated that differ from current, sorted
+// by field name
+func diffFields(current, updated map[string]interface{}) []FieldChange {
+	var changes []FieldChange
+	for field, value := range updated {
+		newValue := formatValue(value)
+		oldValue := ""
+		if old, ok := current[field]; ok {
+			oldValue = formatValue(old)
+		}
+		if oldValue != newValue {
+			changes = append(changes, FieldChange{Field: field, Old: oldValue, New: newValue})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Field < changes[j].Field
+	})
+	return changes
+}
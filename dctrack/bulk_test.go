@@ -0,0 +1,201 @@
+package dctrack
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestItemInputFromFields(t *testing.T) {
+	input, err := ItemInputFromFields(map[string]interface{}{
+		"tiName":                     "web-01",
+		"tiSerialNumber":             "SN-1",
+		"cmbUPosition":               12.0, // JSON number
+		"tiPurchasePrice":            "2500.50",
+		"installationDate":           "2024-03-15",
+		"cmbCabinet":                 "", // Blank cell
+		"tiCustomField_Asset Status": "Active",
+		"tiRackUnitCount":            "2",
+	})
+	if err != nil {
+		t.Fatalf("ItemInputFromFields failed: %v", err)
+	}
+
+	if input.Name == nil || *input.Name != "web-01" {
+		t.Errorf("Expected name web-01, got %v", input.Name)
+	}
+	if input.UPosition == nil || *input.UPosition != "12" {
+		t.Errorf("Expected position 12, got %v", input.UPosition)
+	}
+	if input.Price == nil || *input.Price != 2500.5 {
+		t.Errorf("Expected price 2500.5, got %v", input.Price)
+	}
+	if input.InstallationDate == nil || input.InstallationDate.Format("2006-01-02") != "2024-03-15" {
+		t.Errorf("Expected installation date, got %v", input.InstallationDate)
+	}
+	if input.Cabinet != nil {
+		t.Errorf("Expected blank cabinet to be skipped, got %s", *input.Cabinet)
+	}
+	if input.CustomFields["Asset Status"] != "Active" {
+		t.Errorf("Expected custom field in CustomFields, got %v", input.CustomFields)
+	}
+	if input.Extra["tiRackUnitCount"] != "2" {
+		t.Errorf("Expected unknown key in Extra, got %v", input.Extra)
+	}
+
+	if _, err := ItemInputFromFields(map[string]interface{}{"purchaseDate": "last week"}); err == nil {
+		t.Errorf("Expected error for invalid date")
+	}
+}
+
+func TestBulkUpsertItems(t *testing.T) {
+	store, server := newItemStore(t)
+	defer server.Close()
+	store.items["7"] = map[string]interface{}{
+		"id": "7", "tiName": "db-01", "tiClass": "Device", "cmbLocation": "RDU2",
+		"tiSerialNumber": "SN-7", "cmbCabinet": "CAB-A1",
+	}
+	store.items["8"] = map[string]interface{}{
+		"id": "8", "tiName": "db-02", "tiClass": "Device", "cmbLocation": "RDU2",
+		"tiAssetTag": "AT-8", "cmbCabinet": "CAB-A1",
+	}
+
+	client, err := New(server.URL+"/api/v2", "testuser", "testpass")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	inputs := []ItemInput{
+		{SerialNumber: Ptr("SN-7"), Cabinet: Ptr("CAB-B2")},                                                 // Update by serial
+		{AssetTag: Ptr("AT-8"), Cabinet: Ptr("CAB-A1")},                                                     // Unchanged
+		{SerialNumber: Ptr("SN-NEW"), ItemClass: Ptr("Device"), Name: Ptr("web-01"), Location: Ptr("RDU2")}, // Create
+		{Name: Ptr("no-keys")},                             // Skipped
+		{SerialNumber: Ptr("SN-BAD"), Name: Ptr("web-02")}, // Missing required fields
+		{SerialNumber: Ptr("SN-7"), Name: Ptr("again")},    // Duplicate row
+	}
+
+	var progress []int
+	report, err := client.BulkUpsertItems(context.Background(), inputs, BulkOptions{
+		BatchSize:   4,
+		Concurrency: 2,
+		Progress:    func(done, total int) { progress = append(progress, done) },
+	})
+	if err != nil {
+		t.Fatalf("BulkUpsertItems failed: %v", err)
+	}
+
+	expected := []BulkAction{BulkUpdated, BulkSkipped, BulkCreated, BulkSkipped, BulkFailed, BulkFailed}
+	for i, action := range expected {
+		if report.Rows[i].Row != i || report.Rows[i].Action != action {
+			t.Errorf("Row %d: expected %s, got %+v", i, action, report.Rows[i])
+		}
+	}
+	if report.Created != 1 || report.Updated != 1 || report.Skipped != 2 || report.Failed != 2 {
+		t.Errorf("Unexpected totals: %+v", report)
+	}
+
+	if report.Rows[0].ID != "7" || len(report.Rows[0].Changes) != 1 || report.Rows[0].Changes[0] != (FieldChange{"cmbCabinet", "CAB-A1", "CAB-B2"}) {
+		t.Errorf("Expected cabinet change on item 7, got %+v", report.Rows[0])
+	}
+	if store.items["7"]["cmbCabinet"] != "CAB-B2" || store.items["7"]["tiName"] != "db-01" {
+		t.Errorf("Expected only the cabinet of item 7 to change, got %v", store.items["7"])
+	}
+	if report.Rows[2].ID != "101" {
+		t.Errorf("Expected created item ID 101, got %s", report.Rows[2].ID)
+	}
+	if !errors.Is(report.Rows[4].Err, ErrValidation) {
+		t.Errorf("Expected validation error for row 4, got %v", report.Rows[4].Err)
+	}
+	if report.Rows[5].Reason != "duplicate of row 0" {
+		t.Errorf("Expected duplicate reason, got %s", report.Rows[5].Reason)
+	}
+	if len(progress) != 2 || progress[0] != 4 || progress[1] != 6 {
+		t.Errorf("Expected progress after each batch, got %v", progress)
+	}
+}
+
+func TestBulkUpsertItemsLimitedFields(t *testing.T) {
+	// The minimal column set selects neither serial numbers nor asset tags,
+	// and the store only returns the selected columns
+	store, server := newItemStore(t)
+	defer server.Close()
+	store.items["7"] = map[string]interface{}{
+		"id": "7", "tiName": "db-01", "tiClass": "Device", "cmbLocation": "RDU2",
+		"tiSerialNumber": "SN-7", "cmbCabinet": "CAB-A1", "cmbUPosition": "10",
+	}
+	store.items["8"] = map[string]interface{}{
+		"id": "8", "tiName": "db-02", "tiClass": "Device", "cmbLocation": "RDU2",
+		"tiAssetTag": "AT-8", "cmbCabinet": "CAB-A1",
+	}
+
+	client, err := New(server.URL+"/api/v2", "testuser", "testpass", WithLimitedFields())
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	inputs := []ItemInput{
+		{SerialNumber: Ptr("SN-7"), Cabinet: Ptr("CAB-A1"), UPosition: Ptr("20")},
+		{AssetTag: Ptr("AT-8"), Name: Ptr("db-02"), Cabinet: Ptr("CAB-A1")},
+	}
+	report, err := client.BulkUpsertItems(context.Background(), inputs, BulkOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("BulkUpsertItems failed: %v", err)
+	}
+
+	if report.Rows[0].Action != BulkUpdated || report.Rows[0].ID != "7" {
+		t.Errorf("Expected item 7 to be updated, got %+v", report.Rows[0])
+	}
+	changes := report.Rows[0].Changes
+	if len(changes) != 1 || changes[0] != (FieldChange{"cmbUPosition", "10", "20"}) {
+		t.Errorf("Expected only the position to change, got %+v", changes)
+	}
+	if report.Rows[1].Action != BulkSkipped || report.Rows[1].ID != "8" || report.Rows[1].Reason != "unchanged" {
+		t.Errorf("Expected item 8 to be unchanged, got %+v", report.Rows[1])
+	}
+	if report.Created != 0 {
+		t.Errorf("Expected no items to be created, got %d", report.Created)
+	}
+}
+
+func TestBulkUpsertItemsDryRun(t *testing.T) {
+	store, server := newItemStore(t)
+	defer server.Close()
+
+	client, err := New(server.URL+"/api/v2", "testuser", "testpass")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	existing := []DCTrackItem{
+		{ID: "7", Name: "db-01", TiSerialNumber: "SN-7", Cabinet: "CAB-A1", Position: "10"},
+		{ID: "8", Name: "db-02", TiSerialNumber: "SN-DUP"},
+		{ID: "9", Name: "db-03", TiSerialNumber: "SN-DUP"},
+	}
+	inputs := []ItemInput{
+		{SerialNumber: Ptr("SN-7"), Cabinet: Ptr("CAB-B2"), UPosition: Ptr("20")},
+		{SerialNumber: Ptr("SN-NEW"), ItemClass: Ptr("Device"), Name: Ptr("web-01"), Location: Ptr("RDU2")},
+		{SerialNumber: Ptr("SN-DUP"), Name: Ptr("ambiguous")},
+	}
+
+	report, err := client.BulkUpsertItems(context.Background(), inputs, BulkOptions{DryRun: true, Existing: existing})
+	if err != nil {
+		t.Fatalf("BulkUpsertItems failed: %v", err)
+	}
+
+	if !report.DryRun || report.Updated != 1 || report.Created != 1 || report.Failed != 1 {
+		t.Errorf("Unexpected dry-run report: %+v", report)
+	}
+	changes := report.Rows[0].Changes
+	if len(changes) != 2 || changes[0] != (FieldChange{"cmbCabinet", "CAB-A1", "CAB-B2"}) || changes[1] != (FieldChange{"cmbUPosition", "10", "20"}) {
+		t.Errorf("Expected sorted cabinet and position changes, got %+v", changes)
+	}
+	if len(report.Rows[1].Changes) != 4 {
+		t.Errorf("Expected every field of the new item in its diff, got %+v", report.Rows[1].Changes)
+	}
+	if report.Rows[2].Err == nil {
+		t.Errorf("Expected ambiguous match error")
+	}
+	if store.writes != 0 || store.searches != 0 {
+		t.Errorf("Expected no requests, got %d writes and %d searches", store.writes, store.searches)
+	}
+}
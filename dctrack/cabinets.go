@@ -0,0 +1,387 @@
+package dctrack
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// cabinetClass is the tiClass DCTrack gives cabinets
+const cabinetClass = "Cabinet"
+
+// cabinetColumns are the quicksearch columns a Cabinet is built from
+var cabinetColumns = []string{
+	"id", "tiName", "tiClass", "cmbLocation", "cmbStatus", "cmbMake", "cmbModel",
+	"tiRUs", "cmbRowLabel", "cmbRowPosition", "tiRoomName", "tiRoomNodeCode",
+	"tiFloorName", "tiFloorNodeCode", "tiPowerCapacity", "tiWidth", "tiDepth",
+}
+
+// cabinetItemColumns are the quicksearch columns an elevation is built from
+var cabinetItemColumns = []string{
+	"id", "tiName", "tiClass", "cmbLocation", "cmbStatus", "cmbMake", "cmbModel",
+	"cmbCabinet", "cmbUPosition", "tiRUs", "tiMounting", "radioRailsUsed",
+}
+
+// ListCabinets retrieves the cabinets at location, or everywhere when
+// location is empty
+func (c *Client) ListCabinets(ctx context.Context, location string) ([]Cabinet, error) {
+	params := ItemsParams{Location: location, ItemClass: cabinetClass, Columns: cabinetColumns}
+	payload, err := c.itemsPayload(params)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := c.collectItems(ctx, params, payload)
+	if err != nil {
+		return nil, fmt.Errorf("error listing cabinets: %w", err)
+	}
+
+	var cabinets []Cabinet
+	for _, item := range items {
+		if strings.EqualFold(item.ItemClass, cabinetClass) {
+			cabinets = append(cabinets, CabinetFromItem(item))
+		}
+	}
+
+	c.logger.Info("Fetched cabinets from DCTrack",
+		zap.String("location", location),
+		zap.Int("count", len(cabinets)))
+	return cabinets, nil
+}
+
+// GetCabinet retrieves a single cabinet by item ID. An item that is not a
+// cabinet satisfies errors.Is(err, ErrNotFound).
+func (c *Client) GetCabinet(ctx context.Context, id string) (*Cabinet, error) {
+	item, err := c.GetItemByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(item.ItemClass, cabinetClass) {
+		return nil, fmt.Errorf("item %s is a %s, not a cabinet: %w", id, item.ItemClass, ErrNotFound)
+	}
+
+	cabinet := CabinetFromItem(*item)
+	return &cabinet, nil
+}
+
+// GetCabinetItems retrieves the items installed in cabinet
+func (c *Client) GetCabinetItems(ctx context.Context, cabinet Cabinet) ([]DCTrackItem, error) {
+	params := ItemsParams{Location: cabinet.Location, Columns: cabinetItemColumns}
+	payload, err := c.itemsPayload(params)
+	if err != nil {
+		return nil, err
+	}
+	withColumnFilter(payload, "cmbCabinet", cabinet.Name)
+
+	items, err := c.collectItems(ctx, params, payload)
+	if err != nil {
+		return nil, fmt.Errorf("error getting items in cabinet %s: %w", cabinet.Name, err)
+	}
+
+	// Cabinet names are only unique within a location, which DCTrack
+	// matches partially
+	var contents []DCTrackItem
+	for _, item := range items {
+		if item.Cabinet == cabinet.Name && (cabinet.Location == "" || item.Location == cabinet.Location) &&
+			item.ID != cabinet.ID {
+			contents = append(contents, item)
+		}
+	}
+	return contents, nil
+}
+
+// GetCabinetElevation fetches a cabinet and its items and lays them out
+func (c *Client) GetCabinetElevation(ctx context.Context, id string) (*CabinetElevation, error) {
+	cabinet, err := c.GetCabinet(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := c.GetCabinetItems(ctx, *cabinet)
+	if err != nil {
+		return nil, err
+	}
+	return NewCabinetElevation(*cabinet, items), nil
+}
+
+// CabinetFromItem reads the cabinet attributes of a cabinet item
+func CabinetFromItem(item DCTrackItem) Cabinet {
+	cabinet := Cabinet{
+		ID:            item.ID,
+		Name:          item.Name,
+		Location:      item.Location,
+		Status:        item.Status,
+		Make:          item.Make,
+		Model:         item.Model,
+		RUHeight:      item.Height,
+		Row:           item.CmbRowLabel,
+		RowPosition:   item.CmbRowPosition,
+		Room:          item.TiRoomName,
+		Floor:         item.TiFloorName,
+		PowerCapacity: item.TiPowerCapacity,
+		Width:         item.TiWidth,
+		Depth:         item.TiDepth,
+	}
+	if cabinet.Room == "" {
+		cabinet.Room = item.TiRoomNodeCode
+	}
+	if cabinet.Floor == "" {
+		cabinet.Floor = item.TiFloorNodeCode
+	}
+	return cabinet
+}
+
+// columnList builds a selectedColumns list
+func columnList(names []string) []map[string]string {
+	columns := make([]map[string]string, len(names))
+	for i, name := range names {
+		columns[i] = map[string]string{"name": name}
+	}
+	return columns
+}
+
+// withColumn adds a column to the selectedColumns of a quicksearch payload
+// unless it is already selected
+func withColumn(payload map[string]interface{}, column string) {
+	columns, _ := payload["selectedColumns"].([]map[string]string)
+	for _, selected := range columns {
+		if selected["name"] == column {
+			return
+		}
+	}
+	payload["selectedColumns"] = append(columns, map[string]string{"name": column})
+}
+
+// withColumnFilter adds an equality filter on column to a quicksearch
+// payload, selecting the column too. DCTrack versions without column
+// filters ignore them and return every row, so fetchPage checks the
+// filtered values again on the results.
+func withColumnFilter(payload map[string]interface{}, column, value string) {
+	withColumn(payload, column)
+	filters, _ := payload["columns"].([]map[string]interface{})
+	payload["columns"] = append(filters, map[string]interface{}{
+		"name":   column,
+		"filter": map[string]string{"eq": value},
+	})
+}
+
+// Face is the side of a cabinet an item is mounted on
+type Face string
+
+const (
+	FaceFront Face = "front"
+	FaceRear  Face = "rear"
+	FaceBoth  Face = "both" // Full-depth items occupy both faces
+)
+
+// URange is an inclusive range of rack units, numbered from 1 at the bottom
+type URange struct {
+	Bottom int `json:"bottom"`
+	Top    int `json:"top"`
+}
+
+// Size returns the number of units in the range
+func (r URange) Size() int {
+	return r.Top - r.Bottom + 1
+}
+
+// Placement is an item occupying a range of units on one or both faces
+type Placement struct {
+	ItemID string `json:"item_id"`
+	Name   string `json:"name"`
+	Range  URange `json:"range"`
+	Face   Face   `json:"face"`
+}
+
+// Overlap reports two placements claiming the same units on the same face
+type Overlap struct {
+	Items [2]string `json:"items"` // Item IDs
+	Range URange    `json:"range"`
+	Face  Face      `json:"face"`
+}
+
+// ElevationUnit is the occupancy of one rack unit
+type ElevationUnit struct {
+	U     int    `json:"u"`
+	Front string `json:"front,omitempty"` // Item ID on the front face, "" when free
+	Rear  string `json:"rear,omitempty"`  // Item ID on the rear face, "" when free
+}
+
+// CabinetElevation lays out the items in a cabinet by rack unit
+type CabinetElevation struct {
+	Cabinet    Cabinet         `json:"cabinet"`
+	Units      []ElevationUnit `json:"units"` // Units[0] is U1
+	Placements []Placement     `json:"placements"`
+	Overlaps   []Overlap       `json:"overlaps"`
+
+	// Unplaced lists items without a U position, such as zero-U PDUs
+	Unplaced []DCTrackItem `json:"unplaced"`
+	// OutOfRange lists placements extending past the cabinet's height
+	OutOfRange []Placement `json:"out_of_range"`
+}
+
+// NewCabinetElevation places items in cabinet by their U position, height
+// and rails used. Items without a numeric position, and zero-U items, are
+// listed as unplaced. An item with no height is taken to be 1U.
+func NewCabinetElevation(cabinet Cabinet, items []DCTrackItem) *CabinetElevation {
+	elevation := &CabinetElevation{
+		Cabinet: cabinet,
+		Units:   make([]ElevationUnit, max(cabinet.RUHeight, 0)),
+	}
+	for i := range elevation.Units {
+		elevation.Units[i].U = i + 1
+	}
+
+	for _, item := range items {
+		bottom, err := strconv.Atoi(strings.TrimSpace(item.Position))
+		if err != nil || bottom < 1 || isZeroU(item) {
+			elevation.Unplaced = append(elevation.Unplaced, item)
+			continue
+		}
+
+		placement := Placement{
+			ItemID: item.ID,
+			Name:   item.Name,
+			Range:  URange{Bottom: bottom, Top: bottom + max(item.Height, 1) - 1},
+			Face:   itemFace(item),
+		}
+		if placement.Range.Top > cabinet.RUHeight {
+			elevation.OutOfRange = append(elevation.OutOfRange, placement)
+		}
+		elevation.Placements = append(elevation.Placements, placement)
+	}
+
+	sort.SliceStable(elevation.Placements, func(i, j int) bool {
+		return elevation.Placements[i].Range.Bottom < elevation.Placements[j].Range.Bottom
+	})
+
+	for i, placement := range elevation.Placements {
+		for _, earlier := range elevation.Placements[:i] {
+			if overlap, ok := overlapOf(earlier, placement); ok {
+				elevation.Overlaps = append(elevation.Overlaps, overlap)
+			}
+		}
+		elevation.occupy(placement)
+	}
+
+	return elevation
+}
+
+// occupy marks the placement's units, keeping the first claimant of a unit
+func (e *CabinetElevation) occupy(p Placement) {
+	for u := p.Range.Bottom; u <= p.Range.Top && u <= len(e.Units); u++ {
+		unit := &e.Units[u-1]
+		if p.Face != FaceRear && unit.Front == "" {
+			unit.Front = p.ItemID
+		}
+		if p.Face != FaceFront && unit.Rear == "" {
+			unit.Rear = p.ItemID
+		}
+	}
+}
+
+// FreeRanges returns the runs of units free on face, bottom to top. For
+// FaceBoth a unit must be free on both faces, as a full-depth item needs.
+func (e *CabinetElevation) FreeRanges(face Face) []URange {
+	return e.ranges(func(unit ElevationUnit) bool {
+		switch face {
+		case FaceFront:
+			return unit.Front == ""
+		case FaceRear:
+			return unit.Rear == ""
+		default:
+			return unit.Front == "" && unit.Rear == ""
+		}
+	})
+}
+
+// OccupiedRanges returns the runs of units used on either face
+func (e *CabinetElevation) OccupiedRanges() []URange {
+	return e.ranges(func(unit ElevationUnit) bool {
+		return unit.Front != "" || unit.Rear != ""
+	})
+}
+
+// Utilization summarizes the space used in the cabinet. A unit counts as
+// used when either face is occupied.
+func (e *CabinetElevation) Utilization() CabinetUtilization {
+	used := 0
+	for _, r := range e.OccupiedRanges() {
+		used += r.Size()
+	}
+
+	utilization := CabinetUtilization{
+		Location:   e.Cabinet.Location,
+		Cabinet:    e.Cabinet.Name,
+		UsedRU:     used,
+		TotalRU:    e.Cabinet.RUHeight,
+		AssetCount: len(e.Placements) + len(e.Unplaced),
+	}
+	if utilization.TotalRU > 0 {
+		utilization.UtilizationPercent = float64(used) / float64(utilization.TotalRU) * 100
+	}
+	return utilization
+}
+
+// ranges collects the runs of units matching match
+func (e *CabinetElevation) ranges(match func(ElevationUnit) bool) []URange {
+	var ranges []URange
+	for _, unit := range e.Units {
+		if !match(unit) {
+			continue
+		}
+		if n := len(ranges); n > 0 && ranges[n-1].Top == unit.U-1 {
+			ranges[n-1].Top = unit.U
+			continue
+		}
+		ranges = append(ranges, URange{Bottom: unit.U, Top: unit.U})
+	}
+	return ranges
+}
+
+// overlapOf reports where a and b claim the same units on a shared face
+func overlapOf(a, b Placement) (Overlap, bool) {
+	bottom := max(a.Range.Bottom, b.Range.Bottom)
+	top := min(a.Range.Top, b.Range.Top)
+	if bottom > top {
+		return Overlap{}, false
+	}
+
+	face := FaceBoth
+	switch {
+	case a.Face == FaceBoth:
+		face = b.Face
+	case b.Face == FaceBoth || a.Face == b.Face:
+		face = a.Face
+	default:
+		return Overlap{}, false // Front and rear halves of the same units
+	}
+
+	return Overlap{
+		Items: [2]string{a.ItemID, b.ItemID},
+		Range: URange{Bottom: bottom, Top: top},
+		Face:  face,
+	}, true
+}
+
+// itemFace reads the rails an item is mounted on, defaulting to both
+func itemFace(item DCTrackItem) Face {
+	switch strings.ToLower(strings.TrimSpace(item.RailsUsed)) {
+	case "front":
+		return FaceFront
+	case "rear", "back":
+		return FaceRear
+	default:
+		return FaceBoth
+	}
+}
+
+// isZeroU reports items mounted outside the rack units, such as vertical PDUs
+func isZeroU(item DCTrackItem) bool {
+	mounting := strings.ToLower(strings.ReplaceAll(item.TiMounting, "-", ""))
+	return mounting == "zerou" || mounting == "nonrackable" || mounting == "freestanding"
+}
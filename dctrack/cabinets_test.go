@@ -0,0 +1,193 @@
+package dctrack
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestNewCabinetElevation(t *testing.T) {
+	cabinet := Cabinet{ID: "1", Name: "CAB-A1", Location: "RDU2", RUHeight: 10}
+	items := []DCTrackItem{
+		{ID: "web", Position: "1", Height: 2},                        // U1-U2, both faces
+		{ID: "switch", Position: "5", Height: 1, RailsUsed: "Front"}, // U5 front
+		{ID: "patch", Position: "5", Height: 1, RailsUsed: "Rear"},   // U5 rear, no clash
+		{ID: "db", Position: "2", Height: 2},                         // U2-U3, clashes with web at U2
+		{ID: "pdu", Position: "0", TiMounting: "ZeroU"},              // Not in a unit
+		{ID: "new", Position: ""},                                    // Not yet placed
+		{ID: "tall", Position: "9", Height: 4},                       // U9-U12 in a 10U cabinet
+	}
+
+	elevation := NewCabinetElevation(cabinet, items)
+
+	if len(elevation.Placements) != 5 {
+		t.Fatalf("Expected 5 placements, got %d", len(elevation.Placements))
+	}
+	if len(elevation.Unplaced) != 2 {
+		t.Errorf("Expected 2 unplaced items, got %d", len(elevation.Unplaced))
+	}
+	if len(elevation.OutOfRange) != 1 || elevation.OutOfRange[0].ItemID != "tall" {
+		t.Errorf("Expected tall item out of range, got %+v", elevation.OutOfRange)
+	}
+
+	expectedOverlaps := []Overlap{{Items: [2]string{"web", "db"}, Range: URange{2, 2}, Face: FaceBoth}}
+	if !reflect.DeepEqual(elevation.Overlaps, expectedOverlaps) {
+		t.Errorf("Expected overlaps %+v, got %+v", expectedOverlaps, elevation.Overlaps)
+	}
+
+	if unit := elevation.Units[1]; unit.Front != "web" || unit.Rear != "web" {
+		t.Errorf("Expected U2 held by its first claimant, got %+v", unit)
+	}
+	if unit := elevation.Units[4]; unit.Front != "switch" || unit.Rear != "patch" {
+		t.Errorf("Expected U5 split between faces, got %+v", unit)
+	}
+
+	tests := []struct {
+		face     Face
+		expected []URange
+	}{
+		{FaceBoth, []URange{{4, 4}, {6, 8}}},
+		{FaceFront, []URange{{4, 4}, {6, 8}}},
+		{FaceRear, []URange{{4, 4}, {6, 8}}},
+	}
+	for _, tt := range tests {
+		if got := elevation.FreeRanges(tt.face); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("Free %s: expected %v, got %v", tt.face, tt.expected, got)
+		}
+	}
+	if got := elevation.OccupiedRanges(); !reflect.DeepEqual(got, []URange{{1, 3}, {5, 5}, {9, 10}}) {
+		t.Errorf("Unexpected occupied ranges %v", got)
+	}
+
+	utilization := elevation.Utilization()
+	if utilization.UsedRU != 6 || utilization.TotalRU != 10 || utilization.UtilizationPercent != 60 || utilization.AssetCount != 7 {
+		t.Errorf("Unexpected utilization %+v", utilization)
+	}
+}
+
+func TestCabinetElevationFaces(t *testing.T) {
+	elevation := NewCabinetElevation(Cabinet{RUHeight: 4}, []DCTrackItem{
+		{ID: "front", Position: "1", Height: 2, RailsUsed: "Front"},
+		{ID: "rear", Position: "2", Height: 2, RailsUsed: "Rear"},
+	})
+
+	if len(elevation.Overlaps) != 0 {
+		t.Errorf("Expected front and rear items not to overlap, got %+v", elevation.Overlaps)
+	}
+	if got := elevation.FreeRanges(FaceFront); !reflect.DeepEqual(got, []URange{{3, 4}}) {
+		t.Errorf("Expected front free U3-U4, got %v", got)
+	}
+	if got := elevation.FreeRanges(FaceRear); !reflect.DeepEqual(got, []URange{{1, 1}, {4, 4}}) {
+		t.Errorf("Expected rear free U1 and U4, got %v", got)
+	}
+	if got := elevation.FreeRanges(FaceBoth); !reflect.DeepEqual(got, []URange{{4, 4}}) {
+		t.Errorf("Expected only U4 free on both faces, got %v", got)
+	}
+}
+
+func TestCabinetAPI(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/authentication/login":
+			w.Header().Set("Authorization", "Bearer mock-token-12345")
+		case "/api/v2/items/1":
+			w.Write([]byte(`{"id":1,"tiName":"CAB-A1","tiClass":"Cabinet","cmbLocation":"RDU2","tiRUs":42,"cmbRowLabel":"A","tiRoomName":"Hall 1","tiFloorNodeCode":"F1","tiPowerCapacity":8.6}`))
+		case "/api/v2/items/2":
+			w.Write([]byte(`{"id":2,"tiName":"web-01","tiClass":"Device"}`))
+		case "/api/v2/quicksearch/items":
+			var payload quicksearchPayload
+			json.NewDecoder(r.Body).Decode(&payload)
+			if len(payload.Columns) != 1 || r.URL.Query().Get("location") != "RDU2" {
+				t.Errorf("Expected one column filter and a location, got %+v %s", payload, r.URL.RawQuery)
+			}
+			if r.URL.Query().Get("pageNumber") != "1" {
+				w.Write([]byte(`{"searchResults":{"items":[]}}`))
+				return
+			}
+
+			// Server ignores the filter and returns everything
+			payload.write(t, w, `[
+				{"id":"1","tiName":"CAB-A1","tiClass":"Cabinet","cmbLocation":"RDU2","tiRUs":"42"},
+				{"id":"3","tiName":"CAB-A2","tiClass":"Cabinet","cmbLocation":"RDU2","tiRUs":"48"},
+				{"id":"2","tiName":"web-01","tiClass":"Device","cmbLocation":"RDU2","cmbCabinet":"CAB-A1","cmbUPosition":"10","tiRUs":"2","radioRailsUsed":"Front"},
+				{"id":"4","tiName":"web-02","tiClass":"Device","cmbLocation":"RDU3","cmbCabinet":"CAB-A1","cmbUPosition":"10","tiRUs":"2"}
+			]`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	// The minimal column set lacks the placement columns
+	client, err := New(server.URL+"/api/v2", "testuser", "testpass", WithLimitedFields())
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx := context.Background()
+
+	cabinets, err := client.ListCabinets(ctx, "RDU2")
+	if err != nil {
+		t.Fatalf("ListCabinets failed: %v", err)
+	}
+	if len(cabinets) != 2 || cabinets[1].Name != "CAB-A2" || cabinets[1].RUHeight != 48 {
+		t.Errorf("Expected the two cabinets, got %+v", cabinets)
+	}
+
+	cabinet, err := client.GetCabinet(ctx, "1")
+	if err != nil {
+		t.Fatalf("GetCabinet failed: %v", err)
+	}
+	expected := Cabinet{ID: "1", Name: "CAB-A1", Location: "RDU2", RUHeight: 42, Row: "A", Room: "Hall 1", Floor: "F1", PowerCapacity: 8.6}
+	if *cabinet != expected {
+		t.Errorf("Expected %+v, got %+v", expected, *cabinet)
+	}
+
+	if _, err := client.GetCabinet(ctx, "2"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for a device, got %v", err)
+	}
+
+	elevation, err := client.GetCabinetElevation(ctx, "1")
+	if err != nil {
+		t.Fatalf("GetCabinetElevation failed: %v", err)
+	}
+	if len(elevation.Placements) != 1 || elevation.Placements[0].ItemID != "2" || elevation.Placements[0].Range != (URange{10, 11}) ||
+		elevation.Placements[0].Face != FaceFront {
+		t.Errorf("Expected only web-01 at U10-U11 on the front, got %+v", elevation.Placements)
+	}
+}
+
+// quicksearchPayload is the part of a quicksearch body fake servers read
+type quicksearchPayload struct {
+	SelectedColumns []struct {
+		Name string `json:"name"`
+	} `json:"selectedColumns"`
+	Columns []struct {
+		Name   string            `json:"name"`
+		Filter map[string]string `json:"filter"`
+	} `json:"columns"`
+}
+
+// write responds with records holding only the selected columns, as
+// DCTrack does
+func (p quicksearchPayload) write(t *testing.T, w http.ResponseWriter, records string) {
+	t.Helper()
+	var all []map[string]interface{}
+	if err := json.Unmarshal([]byte(records), &all); err != nil {
+		t.Fatalf("Invalid records: %v", err)
+	}
+
+	items := make([]map[string]interface{}, len(all))
+	for i, record := range all {
+		items[i] = make(map[string]interface{})
+		for _, column := range p.SelectedColumns {
+			if value, ok := record[column.Name]; ok {
+				items[i][column.Name] = value
+			}
+		}
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{"searchResults": map[string]interface{}{"items": items}})
+}
@@ -6,18 +6,32 @@ import (
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"sync"
 	"time"
 
 	"go.uber.org/zap"
 )
 
+// Version information
+const (
+	Version   = "v1.0.0"
+	UserAgent = "go-dctrack-client/v1.0.0"
+)
+
 // Client provides DCTrack API access
 type Client struct {
 	config     Config
 	httpClient *http.Client
 	logger     *zap.Logger
 	token      string // Store the JWT token after login
+
+	authMu      sync.Mutex // Guards token and tokenExpiry
+	tokenExpiry time.Time  // Zero when the token carries no exp claim
+
+	limiter *rateLimiter // Nil when RateLimit is unset
 }
 
 // NewClient creates a new DCTrack API client
@@ -44,6 +58,7 @@ func NewClient(cfg Config, logger *zap.Logger) *Client {
 		config:     cfg,
 		httpClient: httpClient,
 		logger:     logger,
+		limiter:    newRateLimiter(cfg.RateLimit),
 	}
 }
 
@@ -64,33 +79,59 @@ func New(url, username, password string, opts ...Option) (*Client, error) {
 	return NewClient(config, zap.NewNop()), nil
 }
 
-// login authenticates with DCTrack API and stores the JWT token
+// SetLogger sets a custom logger for the client
+func (c *Client) SetLogger(logger *zap.Logger) {
+	if logger != nil {
+		c.logger = logger
+	}
+}
+
+// Config returns the configuration the client was created with
+func (c *Client) Config() Config {
+	return c.config
+}
+
+// Close releases idle connections held by the client
+func (c *Client) Close() error {
+	c.httpClient.CloseIdleConnections()
+	return nil
+}
+
+// login authenticates with DCTrack API and stores the JWT token.
+// Callers other than tests should go through ensureToken.
 func (c *Client) login(ctx context.Context) error {
 	loginURL := fmt.Sprintf("%s/authentication/login", c.config.URL)
 
-	req, err := http.NewRequestWithContext(ctx, "POST", loginURL, nil)
+	creds, err := c.credentials(ctx)
 	if err != nil {
-		return fmt.Errorf("error creating login request: %w", err)
+		return err
 	}
 
-	// Use Basic Auth for login
-	req.SetBasicAuth(c.config.Username, c.config.Password)
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
-
 	c.logger.Debug("Making DCTrack login request",
 		zap.String("method", "POST"),
 		zap.String("url", loginURL),
-		zap.String("username", c.config.Username))
+		zap.String("username", creds.Username))
+
+	resp, err := c.do(ctx, "login", true, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, "POST", loginURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error creating login request: %w", err)
+		}
 
-	resp, err := c.httpClient.Do(req)
+		// Use Basic Auth for login
+		req.SetBasicAuth(creds.Username, creds.Password)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "application/json")
+		req.Header.Set("User-Agent", UserAgent)
+		return req, nil
+	})
 	if err != nil {
 		return fmt.Errorf("error making login request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("login failed with status %d", resp.StatusCode)
+		return newAPIError(resp)
 	}
 
 	// Extract Bearer token from Authorization header
@@ -102,8 +143,10 @@ func (c *Client) login(ctx context.Context) error {
 	// Extract token from "Bearer <token>" format
 	if len(authHeader) > 7 && authHeader[:7] == "Bearer " {
 		c.token = authHeader[7:]
+		c.tokenExpiry, _ = TokenExpiry(c.token)
 		c.logger.Debug("Successfully obtained DCTrack token",
-			zap.Int("token_length", len(c.token)))
+			zap.Int("token_length", len(c.token)),
+			zap.Time("expires_at", c.tokenExpiry))
 		return nil
 	}
 
@@ -119,12 +162,40 @@ func (c *Client) GetItems(ctx context.Context) ([]DCTrackItem, error) {
 
 // GetItemsWithParams retrieves items with specific parameters
 func (c *Client) GetItemsWithParams(ctx context.Context, params ItemsParams) ([]DCTrackItem, error) {
-	// Login first to get the JWT token
-	if err := c.login(ctx); err != nil {
-		return nil, fmt.Errorf("error logging in to DCTrack: %w", err)
+	payload, err := c.itemsPayload(params)
+	if err != nil {
+		return nil, fmt.Errorf("error selecting columns: %w", err)
+	}
+	return c.collectItems(ctx, params, payload)
+}
+
+// collectItems fetches every page of the quicksearch matching params,
+// sending payload as the request body
+func (c *Client) collectItems(ctx context.Context, params ItemsParams, payload map[string]interface{}) ([]DCTrackItem, error) {
+	pages, err := c.collectPages(ctx, params, payload)
+	if err != nil {
+		return nil, err
 	}
 
 	var allItems []DCTrackItem
+	for _, page := range pages {
+		allItems = append(allItems, page.items...)
+	}
+
+	c.logger.Info("Fetched items from DCTrack", zap.Int("count", len(allItems)))
+	return allItems, nil
+}
+
+// collectPages fetches every page of the quicksearch matching params in
+// page order, sending payload as the request body
+func (c *Client) collectPages(ctx context.Context, params ItemsParams, payload map[string]interface{}) ([]*itemsPage, error) {
+	// Reuse the cached JWT, logging in only when needed
+	if _, err := c.ensureToken(ctx); err != nil {
+		return nil, fmt.Errorf("error logging in to DCTrack: %w", err)
+	}
+
+	var pages []*itemsPage
+	total := 0
 	page := 1
 	if params.PageNumber > 0 {
 		page = params.PageNumber
@@ -136,72 +207,50 @@ func (c *Client) GetItemsWithParams(ctx context.Context, params ItemsParams) ([]
 	}
 
 	for {
-		url := fmt.Sprintf("%s/quicksearch/items?pageNumber=%d&pageSize=%d",
-			c.config.URL, page, pageSize)
-
-		// Add optional query parameters
-		if params.Location != "" {
-			url += "&location=" + params.Location
-		}
-		if params.Status != "" {
-			url += "&status=" + params.Status
-		}
-		if params.SearchText != "" {
-			url += "&searchText=" + params.SearchText
-		}
-
-		payload := c.buildStandardFieldsPayload()
-
-		items, err := c.fetchPage(ctx, url, payload)
+		result, err := c.fetchPage(ctx, c.itemsURL(params, page, pageSize), payload)
 		if err != nil {
 			return nil, err
 		}
 
-		if len(items) == 0 {
+		if result.records == 0 {
 			break
 		}
 
-		allItems = append(allItems, items...)
+		pages = append(pages, result)
+		total += len(result.items)
 
 		c.logger.Info("Fetched DCTrack page",
 			zap.Int("page", page),
-			zap.Int("items_count", len(items)),
-			zap.Int("total_items", len(allItems)))
+			zap.Int("items_count", len(result.items)),
+			zap.Int("total_items", total))
 
 		// If we got fewer items than requested, we're done
-		if len(items) < pageSize {
+		if result.records < pageSize {
 			break
 		}
 
-		page++
-
 		// If single page requested, stop after first page
 		if params.PageNumber > 0 {
 			break
 		}
-	}
-
-	c.logger.Info("Fetched items from DCTrack", zap.Int("count", len(allItems)))
-	return allItems, nil
-}
-
-// GetItemByID retrieves a specific item by ID
-func (c *Client) GetItemByID(ctx context.Context, id string) (*DCTrackItem, error) {
-	items, err := c.GetItemsWithParams(ctx, ItemsParams{
-		SearchText: id,
-		PageSize:   1,
-	})
-	if err != nil {
-		return nil, err
-	}
 
-	for _, item := range items {
-		if item.ID == id {
-			return &item, nil
+		// Once totalRows is known the remaining pages can be fetched in parallel
+		if c.config.Concurrency > 1 && result.totalRows > 0 {
+			lastPage := (result.totalRows + pageSize - 1) / pageSize
+			if lastPage > page {
+				rest, err := c.fetchPagesConcurrently(ctx, params, payload, page+1, lastPage, pageSize)
+				if err != nil {
+					return nil, err
+				}
+				pages = append(pages, rest...)
+			}
+			break
 		}
+
+		page++
 	}
 
-	return nil, fmt.Errorf("item with ID %s not found", id)
+	return pages, nil
 }
 
 // SearchItems performs a text search for items
@@ -212,188 +261,198 @@ func (c *Client) SearchItems(ctx context.Context, query string) ([]DCTrackItem,
 	})
 }
 
+// doAuthorized sends an authenticated request, retrying transient failures
+// and logging in again once if DCTrack rejects the cached token. Non-2xx
+// responses are returned as *APIError; the caller must close the body.
+func (c *Client) doAuthorized(ctx context.Context, operation, method, url string, idempotent bool, body []byte) (*http.Response, error) {
+	reauthenticated := false
+	for {
+		token, err := c.ensureToken(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error logging in to DCTrack: %w", err)
+		}
+
+		resp, err := c.do(ctx, operation, idempotent, func() (*http.Request, error) {
+			if err := c.limiter.wait(ctx); err != nil {
+				return nil, err
+			}
+
+			c.logger.Debug("Making DCTrack request",
+				zap.String("method", method),
+				zap.String("url", url),
+				zap.String("username", c.config.Username))
+
+			var reader io.Reader
+			if body != nil {
+				reader = bytes.NewReader(body)
+			}
+			req, err := http.NewRequestWithContext(ctx, method, url, reader)
+			if err != nil {
+				return nil, fmt.Errorf("error creating request: %w", err)
+			}
+
+			// Add headers
+			if body != nil {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			req.Header.Set("Accept", "application/json")
+			req.Header.Set("Authorization", "Bearer "+token)
+			req.Header.Set("User-Agent", UserAgent)
+			return req, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		// The token may have been revoked or expired server-side mid-pagination
+		if resp.StatusCode == http.StatusUnauthorized && !reauthenticated {
+			reauthenticated = true
+			resp.Body.Close()
+			c.invalidateToken(token)
+			continue
+		}
+
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			defer resp.Body.Close()
+			return nil, newAPIError(resp)
+		}
+
+		return resp, nil
+	}
+}
+
+// itemsURL builds the /quicksearch/items URL for one page of params,
+// escaping the filter values
+func (c *Client) itemsURL(params ItemsParams, page, pageSize int) string {
+	endpoint := fmt.Sprintf("%s/quicksearch/items?pageNumber=%d&pageSize=%d",
+		c.config.URL, page, pageSize)
+
+	// Add optional query parameters
+	if params.Location != "" {
+		endpoint += "&location=" + url.QueryEscape(params.Location)
+	}
+	if params.SearchText != "" {
+		endpoint += "&searchText=" + url.QueryEscape(params.SearchText)
+	}
+
+	return endpoint
+}
+
+// itemsPage holds one decoded page of quicksearch results
+type itemsPage struct {
+	items     []DCTrackItem
+	raw       []map[string]interface{} // Records as decoded, for projections
+	records   int                      // Raw records in the page, including any that failed to map
+	totalRows int                      // Total matching rows reported by DCTrack, 0 when unknown
+}
+
 // fetchPage fetches a single page of data from DCTrack API
-func (c *Client) fetchPage(ctx context.Context, url string, payload interface{}) ([]DCTrackItem, error) {
+func (c *Client) fetchPage(ctx context.Context, url string, payload map[string]interface{}) (*itemsPage, error) {
 	payloadBytes, err := json.Marshal(payload)
 	if err != nil {
 		return nil, fmt.Errorf("error marshaling payload: %w", err)
 	}
 
-	// Retry logic
-	var lastErr error
-	for attempt := 1; attempt <= c.config.MaxRetries; attempt++ {
-		c.logger.Debug("Making DCTrack request",
-			zap.String("method", "POST"),
-			zap.String("url", url),
-			zap.String("username", c.config.Username),
-			zap.Int("attempt", attempt))
+	resp, err := c.doAuthorized(ctx, "items", "POST", url, true, payloadBytes)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Read response body first for debugging
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response body: %w", err)
+	}
 
-		req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(payloadBytes))
-		if err != nil {
-			return nil, fmt.Errorf("error creating request: %w", err)
-		}
+	c.logger.Debug("DCTrack raw response received",
+		zap.Int("response_size", len(bodyBytes)),
+		zap.String("response_preview", string(bodyBytes)[:minInt(200, len(bodyBytes))]))
+
+	// Parse response
+	var dctrackResp DCTrackResponse
+	if err := json.Unmarshal(bodyBytes, &dctrackResp); err != nil {
+		c.logger.Error("Failed to decode DCTrack response",
+			zap.Error(err),
+			zap.String("response_preview", string(bodyBytes)[:minInt(500, len(bodyBytes))]))
+		return nil, fmt.Errorf("error decoding response: %w", err)
+	}
 
-		// Add headers
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("Accept", "application/json")
-		req.Header.Set("Authorization", "Bearer "+c.token)
+	all := dctrackResp.Items()
+	c.logger.Debug("DCTrack API response parsed",
+		zap.Int("total_rows", dctrackResp.TotalRows),
+		zap.Int("page_number", dctrackResp.PageNumber),
+		zap.Int("page_size", dctrackResp.PageSize),
+		zap.Int("items_in_response", len(all)))
+
+	records := matchingRecords(all, payload)
 
-		resp, err := c.httpClient.Do(req)
+	var items []DCTrackItem
+	for i, record := range records {
+		item, err := c.mapDCTrackRecord(record)
 		if err != nil {
-			lastErr = err
-			if attempt < c.config.MaxRetries {
-				c.logger.Warn("Request failed, retrying",
-					zap.Error(err),
-					zap.Int("attempt", attempt),
-					zap.Duration("retry_delay", c.config.RetryDelay))
-				time.Sleep(c.config.RetryDelay)
-				continue
-			}
-			break
+			c.logger.Warn("Error mapping DCTrack record",
+				zap.Int("record_index", i),
+				zap.Error(err))
+			continue
 		}
-		defer resp.Body.Close()
+		items = append(items, item)
+	}
 
-		if resp.StatusCode != http.StatusOK {
-			lastErr = fmt.Errorf("request failed with status %d", resp.StatusCode)
-			if attempt < c.config.MaxRetries {
-				time.Sleep(c.config.RetryDelay)
-				continue
-			}
-			break
-		}
+	return &itemsPage{items: items, raw: records, records: len(all), totalRows: dctrackResp.TotalRows}, nil
+}
 
-		// Parse response
-		var dctrackResp DCTrackResponse
-		if err := json.NewDecoder(resp.Body).Decode(&dctrackResp); err != nil {
-			return nil, fmt.Errorf("error decoding response: %w", err)
-		}
+// matchingRecords drops the records whose value of a filtered column
+// differs from the filter, as DCTrack versions without column filters
+// return every row. Records without the column are kept.
+func matchingRecords(records []map[string]interface{}, payload map[string]interface{}) []map[string]interface{} {
+	filters, _ := payload["columns"].([]map[string]interface{})
+	if len(filters) == 0 {
+		return records
+	}
 
-		var items []DCTrackItem
-		for _, record := range dctrackResp.Records {
-			item, err := c.mapDCTrackRecord(record)
-			if err != nil {
-				c.logger.Warn("Error mapping DCTrack record", zap.Error(err))
-				continue
+	var matched []map[string]interface{}
+	for _, record := range records {
+		keep := true
+		for _, filter := range filters {
+			column, _ := filter["name"].(string)
+			condition, _ := filter["filter"].(map[string]string)
+			if value, ok := record[column]; ok && value != nil && formatValue(value) != condition["eq"] {
+				keep = false
+				break
 			}
-			items = append(items, item)
 		}
-
-		return items, nil
+		if keep {
+			matched = append(matched, record)
+		}
 	}
-
-	return nil, fmt.Errorf("all retry attempts failed, last error: %w", lastErr)
+	return matched
 }
 
-// buildStandardFieldsPayload creates the standard field selection payload
+// buildStandardFieldsPayload creates the standard field selection payload,
+// adding the custom fields requested in the config
 func (c *Client) buildStandardFieldsPayload() map[string]interface{} {
+	payload := c.standardFieldsPayload()
+	for _, name := range c.config.CustomFields {
+		withColumn(payload, CustomFieldColumn(name))
+	}
+	return payload
+}
+
+// standardFieldsPayload selects the minimal or full standard columns
+func (c *Client) standardFieldsPayload() map[string]interface{} {
 	if !c.config.RequestAllFields {
 		// Minimal field set for better performance
-		return map[string]interface{}{
-			"selectedColumns": []map[string]string{
-				{"name": "id"},
-				{"name": "tiName"},
-				{"name": "cmbLocation"},
-				{"name": "cmbStatus"},
-				{"name": "tiClass"},
-				{"name": "cmbMake"},
-				{"name": "cmbModel"},
-				{"name": "tiItemOriginalPower"},
-				{"name": "lastUpdatedOn"},
-			},
-		}
+		return map[string]interface{}{"selectedColumns": columnList(minimalColumns)}
 	}
 
 	// Full field set (comprehensive data)
-	return map[string]interface{}{
-		"selectedColumns": []map[string]string{
-			// Core fields
-			{"name": "id"},
-			{"name": "cmbLocation"},
-			{"name": "tiClass"},
-			{"name": "cmbStatus"},
-			{"name": "tiName"},
-			{"name": "cmbMake"},
-			{"name": "cmbModel"},
-			{"name": "cmbCabinet"},
-			{"name": "tiSerialNumber"},
-			{"name": "lastUpdatedOn"},
-			{"name": "tiItemOriginalPower"},
-			{"name": "cmbSystemAdminTeam"},
-			{"name": "tiCustomField_Primary Contact"},
-
-			// Asset information
-			{"name": "tiSubclass"},
-			{"name": "tiAssetTag"},
-			{"name": "tiFormFactor"},
-			{"name": "tiMounting"},
-			{"name": "tiWidth"},
-			{"name": "tiDepth"},
-			{"name": "tiWeight"},
-			{"name": "tiRUs"},
-
-			// Power data
-			{"name": "tiPotentialPower"},
-			{"name": "tiEffectivePower"},
-			{"name": "tiPowerCapacity"},
-			{"name": "tiPSRedundancy"},
-			{"name": "tiPurchasePrice"},
-			{"name": "tiContractAmount"},
-
-			// Infrastructure
-			{"name": "cmbPlantBay"},
-			{"name": "cmbCabinetId"},
-			{"name": "cmbRowPosition"},
-			{"name": "cmbRowLabel"},
-			{"name": "tiFloorNodeCode"},
-			{"name": "tiFloorName"},
-			{"name": "tiRoomNodeCode"},
-			{"name": "tiRoomName"},
-
-			// Integration status
-			{"name": "tiIntegrationStatus"},
-			{"name": "tiVMwareIntegrationStatus"},
-			{"name": "tiCmdbIntegrationStatus"},
-			{"name": "tiItemBudgetStatus"},
-			{"name": "chkItemAutoPowerBudget"},
-			{"name": "chkDerateAmps"},
-
-			// Network config
-			{"name": "ipAddresses"},
-			{"name": "ipAddressPortName"},
-			{"name": "tifreeDataPortCount"},
-			{"name": "tifreePowerPortCount"},
-			{"name": "tiPXUsername"},
-			{"name": "tiSnmpWriteCommString"},
-
-			// Technical specs
-			{"name": "tiUsers"},
-			{"name": "tiRAM"},
-			{"name": "tiProcesses"},
-			{"name": "tiCpuQuantity"},
-			{"name": "tiCpuType"},
-			{"name": "tiPartNumber"},
-
-			// Dates
-			{"name": "installationDate"},
-			{"name": "contractEndDate"},
-			{"name": "purchaseDate"},
-			{"name": "tiPlannedDecommDate"},
-
-			// Custom fields
-			{"name": "tiCustomField_Contact Team Name"},
-			{"name": "tiCustomField_Audit Remarks"},
-			{"name": "tiCustomField_Audit Date"},
-			{"name": "tiCustomField_Audit By"},
-			{"name": "tiCustomField_Warranty Expiration Date"},
-			{"name": "tiCustomField_Asset Status"},
-			{"name": "tiCustomField_PNT/IT"},
-
-			// Admin data
-			{"name": "cmbSystemAdminTeam"},
-			{"name": "cmbSystemAdmin"},
-			{"name": "cmbCustomer"},
-			{"name": "tiPONumber"},
-			{"name": "tiNotes"},
-		},
+	return map[string]interface{}{"selectedColumns": columnList(fullColumns)}
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
 	}
+	return b
 }
@@ -0,0 +1,218 @@
+package dctrack
+
+import (
+	"sort"
+	"strings"
+)
+
+// minimalColumns are requested unless Config.RequestAllFields is set
+var minimalColumns = []string{
+	"id",
+	"tiName",
+	"cmbLocation",
+	"cmbStatus",
+	"tiClass",
+	"cmbMake",
+	"cmbModel",
+	"tiItemOriginalPower",
+	"lastUpdatedOn",
+}
+
+// fullColumns are requested when Config.RequestAllFields is set
+var fullColumns = []string{
+	// Core fields
+	"id",
+	"cmbLocation",
+	"tiClass",
+	"cmbStatus",
+	"tiName",
+	"cmbMake",
+	"cmbModel",
+	"cmbCabinet",
+	"cmbUPosition",
+	"tiSerialNumber",
+	"lastUpdatedOn",
+	"tiItemOriginalPower",
+	"cmbSystemAdminTeam",
+	"tiCustomField_Primary Contact",
+
+	// Asset information
+	"tiSubclass",
+	"tiAssetTag",
+	"tiFormFactor",
+	"tiMounting",
+	"radioRailsUsed",
+	"tiWidth",
+	"tiDepth",
+	"tiWeight",
+	"tiRUs",
+
+	// Power data
+	"tiPotentialPower",
+	"tiEffectivePower",
+	"tiPowerCapacity",
+	"tiPSRedundancy",
+	"tiPurchasePrice",
+	"tiContractAmount",
+
+	// Infrastructure
+	"cmbPlantBay",
+	"cmbCabinetId",
+	"cmbRowPosition",
+	"cmbRowLabel",
+	"tiFloorNodeCode",
+	"tiFloorName",
+	"tiRoomNodeCode",
+	"tiRoomName",
+
+	// Integration status
+	"tiIntegrationStatus",
+	"tiVMwareIntegrationStatus",
+	"tiCmdbIntegrationStatus",
+	"tiItemBudgetStatus",
+	"chkItemAutoPowerBudget",
+	"chkDerateAmps",
+
+	// Network config
+	"ipAddresses",
+	"ipAddressPortName",
+	"tifreeDataPortCount",
+	"tifreePowerPortCount",
+	"tiPXUsername",
+	"tiSnmpWriteCommString",
+
+	// Technical specs
+	"tiUsers",
+	"tiRAM",
+	"tiProcesses",
+	"tiCpuQuantity",
+	"tiCpuType",
+	"tiPartNumber",
+
+	// Dates
+	"installationDate",
+	"contractEndDate",
+	"purchaseDate",
+	"tiPlannedDecommDate",
+
+	// Custom fields
+	"tiCustomField_Contact Team Name",
+	"tiCustomField_Audit Remarks",
+	"tiCustomField_Audit Date",
+	"tiCustomField_Audit By",
+	"tiCustomField_Warranty Expiration Date",
+	"tiCustomField_Asset Status",
+	"tiCustomField_PNT/IT",
+
+	// Admin data
+	"cmbSystemAdminTeam",
+	"cmbSystemAdmin",
+	"cmbCustomer",
+	"tiPONumber",
+	"tiNotes",
+}
+
+// knownColumns is the registry of quicksearch columns the client can
+// request: the full set plus the columns the record mapper reads besides
+var knownColumns = func() map[string]bool {
+	known := make(map[string]bool, len(fullColumns)+2)
+	for _, name := range fullColumns {
+		known[name] = true
+	}
+	known["cmbPosition"] = true
+	known["lastServiceDate"] = true
+	return known
+}()
+
+// KnownColumns returns the registered quicksearch column names, sorted
+func KnownColumns() []string {
+	names := make([]string, 0, len(knownColumns))
+	for name := range knownColumns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// IsKnownColumn reports whether name is a registered column or a custom
+// field column. Column names are case-sensitive.
+func IsKnownColumn(name string) bool {
+	return knownColumns[name] || (strings.HasPrefix(name, customFieldPrefix) && len(name) > len(customFieldPrefix))
+}
+
+// ValidateColumns checks column names against the registry, reporting
+// every unknown one as a *ValidationError
+func ValidateColumns(columns []string) error {
+	var problems []ErrorDetail
+	for _, name := range columns {
+		if IsKnownColumn(name) {
+			continue
+		}
+		message := "is not a known column"
+		if suggestion := suggestColumn(name); suggestion != "" {
+			message += ", did you mean " + suggestion + "?"
+		}
+		problems = append(problems, ErrorDetail{Field: name, Message: message})
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Errors: problems}
+	}
+	return nil
+}
+
+// suggestColumn finds the registered column differing from name only in
+// case, as in "cmblocation" for "cmbLocation"
+func suggestColumn(name string) string {
+	for known := range knownColumns {
+		if strings.EqualFold(known, name) {
+			return known
+		}
+	}
+	return ""
+}
+
+// strictColumns are the columns strict mapping requires on every record
+var strictColumns = []string{"id", "tiName", "tiClass", "cmbStatus", "cmbLocation"}
+
+// columnsPayload selects exactly columns, plus the id the mapper requires,
+// or every column strict mapping checks when it is on
+func (c *Client) columnsPayload(columns []string) map[string]interface{} {
+	required := []string{"id"}
+	if c.config.StrictMapping {
+		required = strictColumns
+	}
+
+	payload := map[string]interface{}{"selectedColumns": columnList(required)}
+	for _, column := range columns {
+		withColumn(payload, column)
+	}
+	return payload
+}
+
+// itemsPayload builds the quicksearch body for params: the caller's
+// columns when given, validated against the registry, otherwise the
+// standard set, with a column filter for each filter in params but
+// Location, which itemsURL sends as the partially matched location
+// parameter
+func (c *Client) itemsPayload(params ItemsParams) (map[string]interface{}, error) {
+	payload := c.buildStandardFieldsPayload()
+	if len(params.Columns) > 0 {
+		if err := ValidateColumns(params.Columns); err != nil {
+			return nil, err
+		}
+		payload = c.columnsPayload(params.Columns)
+	}
+
+	for _, filter := range []struct{ column, value string }{
+		{"cmbStatus", params.Status},
+		{"tiClass", params.ItemClass},
+		{"cmbMake", params.Make},
+		{"cmbModel", params.Model},
+	} {
+		if filter.value != "" {
+			withColumnFilter(payload, filter.column, filter.value)
+		}
+	}
+	return payload, nil
+}
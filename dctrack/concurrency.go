@@ -0,0 +1,107 @@
+package dctrack
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// rateLimiter spaces requests evenly so that no more than the configured
+// number start per second, shared by every worker of a client
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time // Earliest start of the next request
+}
+
+// newRateLimiter returns nil, which never waits, when perSecond is not positive
+func newRateLimiter(perSecond float64) *rateLimiter {
+	if perSecond <= 0 {
+		return nil
+	}
+	return &rateLimiter{interval: time.Duration(float64(time.Second) / perSecond)}
+}
+
+// wait blocks until the caller's request slot arrives or ctx is done
+func (l *rateLimiter) wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	slot := l.next
+	if slot.Before(now) {
+		slot = now
+	}
+	l.next = slot.Add(l.interval)
+	l.mu.Unlock()
+
+	return sleepContext(ctx, time.Until(slot))
+}
+
+// fetchPagesConcurrently fetches pages first through last with up to
+// Concurrency workers and returns them in page order. The first
+// failure cancels the pages still in flight and is returned as a *PageError.
+func (c *Client) fetchPagesConcurrently(ctx context.Context, params ItemsParams, payload map[string]interface{}, first, last, pageSize int) ([]*itemsPage, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([]*itemsPage, last-first+1)
+	workers := c.config.Concurrency
+	if workers > len(results) {
+		workers = len(results)
+	}
+
+	c.logger.Debug("Fetching DCTrack pages concurrently",
+		zap.Int("first_page", first),
+		zap.Int("last_page", last),
+		zap.Int("workers", workers))
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	pages := make(chan int)
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for page := range pages {
+				result, err := c.fetchPage(ctx, c.itemsURL(params, page, pageSize), payload)
+				if err != nil {
+					errOnce.Do(func() {
+						firstErr = &PageError{Page: page, Err: err}
+						cancel()
+					})
+					return
+				}
+				results[page-first] = result
+			}
+		}()
+	}
+
+feed:
+	for page := first; page <= last; page++ {
+		select {
+		case pages <- page:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(pages)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
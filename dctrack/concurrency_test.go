@@ -0,0 +1,165 @@
+package dctrack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// concurrencyServer serves total items, delaying later pages less than
+// earlier ones so that responses complete out of order
+type concurrencyServer struct {
+	total    int
+	failPage int
+
+	inFlight    atomic.Int32
+	maxInFlight atomic.Int32
+	requests    atomic.Int32
+}
+
+func (s *concurrencyServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/api/v2/authentication/login":
+		w.Header().Set("Authorization", "Bearer mock-token-12345")
+		w.WriteHeader(http.StatusOK)
+	case "/api/v2/quicksearch/items":
+		s.requests.Add(1)
+		current := s.inFlight.Add(1)
+		defer s.inFlight.Add(-1)
+		for {
+			max := s.maxInFlight.Load()
+			if current <= max || s.maxInFlight.CompareAndSwap(max, current) {
+				break
+			}
+		}
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("pageNumber"))
+		size, _ := strconv.Atoi(r.URL.Query().Get("pageSize"))
+		if page == s.failPage {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		if page > 1 {
+			time.Sleep(time.Duration(20-page%10) * time.Millisecond)
+		}
+
+		var records []string
+		for i := (page - 1) * size; i < page*size && i < s.total; i++ {
+			records = append(records, fmt.Sprintf(`{"id":"%d","tiName":"item-%d"}`, i, i))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"totalRows":%d,"searchResults":{"items":[%s]}}`, s.total, strings.Join(records, ","))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestConcurrentPagesPreserveOrder(t *testing.T) {
+	backend := &concurrencyServer{total: 95}
+	server := httptest.NewServer(backend)
+	defer server.Close()
+
+	client, err := New(server.URL+"/api/v2", "testuser", "testpass",
+		WithPageSize(10), WithConcurrency(4))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	items, err := client.GetItems(context.Background())
+	if err != nil {
+		t.Fatalf("GetItems failed: %v", err)
+	}
+
+	if len(items) != 95 {
+		t.Fatalf("Expected 95 items, got %d", len(items))
+	}
+	for i, item := range items {
+		if item.ID != strconv.Itoa(i) {
+			t.Fatalf("Expected item %d at position %d, got %s", i, i, item.ID)
+		}
+	}
+	if backend.requests.Load() != 10 {
+		t.Errorf("Expected 10 page requests, got %d", backend.requests.Load())
+	}
+	if max := backend.maxInFlight.Load(); max > 4 || max < 2 {
+		t.Errorf("Expected between 2 and 4 requests in flight, got %d", max)
+	}
+}
+
+func TestConcurrentPagesRateLimit(t *testing.T) {
+	backend := &concurrencyServer{total: 60}
+	server := httptest.NewServer(backend)
+	defer server.Close()
+
+	client, err := New(server.URL+"/api/v2", "testuser", "testpass",
+		WithPageSize(10), WithConcurrency(6), WithRateLimit(50))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	start := time.Now()
+	items, err := client.GetItems(context.Background())
+	if err != nil {
+		t.Fatalf("GetItems failed: %v", err)
+	}
+	elapsed := time.Since(start)
+
+	if len(items) != 60 {
+		t.Errorf("Expected 60 items, got %d", len(items))
+	}
+	// Six requests at 50/s need at least five 20ms intervals
+	if elapsed < 100*time.Millisecond {
+		t.Errorf("Expected rate limit to spread requests over 100ms, took %v", elapsed)
+	}
+}
+
+func TestConcurrentPagesCancelOnError(t *testing.T) {
+	backend := &concurrencyServer{total: 500, failPage: 3}
+	server := httptest.NewServer(backend)
+	defer server.Close()
+
+	client, err := New(server.URL+"/api/v2", "testuser", "testpass",
+		WithPageSize(10), WithConcurrency(2), WithMaxRetries(1))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	_, err = client.GetItems(context.Background())
+
+	var pageErr *PageError
+	if !errors.As(err, &pageErr) {
+		t.Fatalf("Expected *PageError, got %v", err)
+	}
+	if pageErr.Page != 3 {
+		t.Errorf("Expected failure on page 3, got %d", pageErr.Page)
+	}
+	if backend.requests.Load() >= 50 {
+		t.Errorf("Expected remaining pages to be cancelled, got %d requests", backend.requests.Load())
+	}
+}
+
+func TestRateLimiterRespectsContext(t *testing.T) {
+	limiter := newRateLimiter(1)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	if err := limiter.wait(ctx); err != nil {
+		t.Fatalf("First request should not wait: %v", err)
+	}
+
+	cancel()
+	if err := limiter.wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+
+	var unlimited *rateLimiter
+	if err := unlimited.wait(context.Background()); err != nil {
+		t.Errorf("Nil limiter should never wait: %v", err)
+	}
+}
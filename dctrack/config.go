@@ -8,31 +8,56 @@ import (
 // Config holds DCTrack API configuration
 type Config struct {
 	// Connection settings
-	URL      string `json:"url"`      // DCTrack API base URL (e.g., "https://dctrack.company.com/api/v2")
-	Username string `json:"username"` // DCTrack username
-	Password string `json:"password"` // DCTrack password
+	URL      string `json:"url" yaml:"url"`           // DCTrack API base URL (e.g., "https://dctrack.company.com/api/v2")
+	Username string `json:"username" yaml:"username"` // DCTrack username
+	Password string `json:"password" yaml:"password"` // DCTrack password
+
+	// Credential sources, consulted at login time instead of Password
+	PasswordFile       string             `json:"password_file" yaml:"password_file"` // File holding the password (e.g., "~/.secrets/dctrack_password.txt")
+	CredentialProvider CredentialProvider `json:"-" yaml:"-"`                         // Custom credential source, takes precedence over PasswordFile
 
 	// API settings
-	PageSize   int           `json:"page_size"`   // Default page size for API requests (default: 1000)
-	MaxRetries int           `json:"max_retries"` // Maximum number of retry attempts (default: 3)
-	RetryDelay time.Duration `json:"retry_delay"` // Delay between retry attempts (default: 1s)
+	PageSize   int           `json:"page_size" yaml:"page_size"`     // Default page size for API requests (default: 1000)
+	MaxRetries int           `json:"max_retries" yaml:"max_retries"` // Maximum number of retry attempts (default: 3)
+	RetryDelay time.Duration `json:"retry_delay" yaml:"retry_delay"` // Delay between retry attempts (default: 1s)
+
+	// Retry settings, overriding MaxRetries and RetryDelay when set
+	RetryPolicy *RetryPolicy `json:"-" yaml:"-"` // Backoff, jitter and retryable statuses (default: DefaultRetryPolicy)
+	RetryHooks  []RetryHook  `json:"-" yaml:"-"` // Callbacks for every request attempt
 
 	// Security settings
-	VerifySSL        bool `json:"verify_ssl"`         // Whether to verify SSL certificates (default: true)
-	RequestAllFields bool `json:"request_all_fields"` // Whether to request all available fields (default: true)
+	VerifySSL        bool `json:"verify_ssl" yaml:"verify_ssl"`                 // Whether to verify SSL certificates (default: true)
+	RequestAllFields bool `json:"request_all_fields" yaml:"request_all_fields"` // Whether to request all available fields (default: true)
+
+	// Throughput settings
+	Concurrency int     `json:"concurrency" yaml:"concurrency"` // Parallel page fetches and ID lookups (default: 1)
+	RateLimit   float64 `json:"rate_limit" yaml:"rate_limit"`   // Maximum data requests per second, 0 for unlimited (default: 0)
+
+	// Mapping settings
+	StrictMapping bool     `json:"strict_mapping" yaml:"strict_mapping"` // Drop records missing id, tiName, tiClass, cmbStatus or cmbLocation (default: false)
+	CustomFields  []string `json:"custom_fields" yaml:"custom_fields"`   // Custom fields to request by name, e.g. "Audit Date" (default: none)
+
+	// Write settings
+	DryRun bool `json:"dry_run" yaml:"dry_run"` // Validate and preview item writes without sending them (default: false)
+
+	// Session settings
+	TokenRefreshMargin time.Duration `json:"token_refresh_margin" yaml:"token_refresh_margin"` // Refresh the JWT this long before it expires (default: 1m)
+	SessionHooks       []SessionHook `json:"-" yaml:"-"`                                       // Callbacks for login, refresh and expiry events
 }
 
 // DefaultConfig returns a configuration with sensible defaults
 func DefaultConfig(url, username, password string) Config {
 	return Config{
-		URL:              url,
-		Username:         username,
-		Password:         password,
-		PageSize:         1000,
-		MaxRetries:       3,
-		RetryDelay:       time.Second,
-		VerifySSL:        true,
-		RequestAllFields: true,
+		URL:                url,
+		Username:           username,
+		Password:           password,
+		PageSize:           1000,
+		MaxRetries:         3,
+		RetryDelay:         time.Second,
+		VerifySSL:          true,
+		RequestAllFields:   true,
+		Concurrency:        1,
+		TokenRefreshMargin: time.Minute,
 	}
 }
 
@@ -60,6 +85,21 @@ func WithRetryDelay(delay time.Duration) Option {
 	}
 }
 
+// WithRetryPolicy replaces the default backoff policy. Fields are used as
+// given, so start from DefaultRetryPolicy to change only some of them.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Config) {
+		c.RetryPolicy = &policy
+	}
+}
+
+// WithRetryHook registers a callback for every login and data request attempt
+func WithRetryHook(hook RetryHook) Option {
+	return func(c *Config) {
+		c.RetryHooks = append(c.RetryHooks, hook)
+	}
+}
+
 // WithInsecureSSL disables SSL certificate verification
 func WithInsecureSSL() Option {
 	return func(c *Config) {
@@ -74,16 +114,84 @@ func WithLimitedFields() Option {
 	}
 }
 
+// WithConcurrency fetches up to n pages or items in parallel. Pages are
+// fanned out once the first page has reported the total row count.
+func WithConcurrency(n int) Option {
+	return func(c *Config) {
+		c.Concurrency = n
+	}
+}
+
+// WithRateLimit caps data requests at perSecond across all workers
+func WithRateLimit(perSecond float64) Option {
+	return func(c *Config) {
+		c.RateLimit = perSecond
+	}
+}
+
+// WithStrictMapping drops records missing any of the fields required to identify an item
+func WithStrictMapping() Option {
+	return func(c *Config) {
+		c.StrictMapping = true
+	}
+}
+
+// WithCustomFields requests the named custom fields with every item, in
+// addition to the standard columns
+func WithCustomFields(names ...string) Option {
+	return func(c *Config) {
+		c.CustomFields = append(c.CustomFields, names...)
+	}
+}
+
+// WithDryRun makes CreateItem, UpdateItem and DeleteItem validate and
+// return a preview without changing anything in DCTrack
+func WithDryRun() Option {
+	return func(c *Config) {
+		c.DryRun = true
+	}
+}
+
+// WithCredentialProvider fetches credentials from provider at login time
+func WithCredentialProvider(provider CredentialProvider) Option {
+	return func(c *Config) {
+		c.CredentialProvider = provider
+	}
+}
+
+// WithPasswordFile reads the password from path at login time
+func WithPasswordFile(path string) Option {
+	return func(c *Config) {
+		c.PasswordFile = path
+	}
+}
+
+// WithTokenRefreshMargin sets how long before expiry the JWT is refreshed
+func WithTokenRefreshMargin(margin time.Duration) Option {
+	return func(c *Config) {
+		c.TokenRefreshMargin = margin
+	}
+}
+
+// WithSessionHook registers a callback for session login, refresh and expiry events
+func WithSessionHook(hook SessionHook) Option {
+	return func(c *Config) {
+		c.SessionHooks = append(c.SessionHooks, hook)
+	}
+}
+
 // Validate validates the configuration
 func (c *Config) Validate() error {
 	if c.URL == "" {
 		return fmt.Errorf("URL is required")
 	}
-	if c.Username == "" {
-		return fmt.Errorf("Username is required")
-	}
-	if c.Password == "" {
-		return fmt.Errorf("Password is required")
+	if c.CredentialProvider == nil {
+		if c.Username == "" {
+			return fmt.Errorf("Username is required")
+		}
+		if c.Password == "" && c.PasswordFile == "" {
+			return fmt.Errorf("Password is required")
+		}
 	}
 	if c.PageSize <= 0 {
 		c.PageSize = 1000
@@ -94,5 +202,14 @@ func (c *Config) Validate() error {
 	if c.RetryDelay <= 0 {
 		c.RetryDelay = time.Second
 	}
+	if c.Concurrency <= 0 {
+		c.Concurrency = 1
+	}
+	if c.RateLimit < 0 {
+		c.RateLimit = 0
+	}
+	if c.TokenRefreshMargin < 0 {
+		c.TokenRefreshMargin = time.Minute
+	}
 	return nil
 }
@@ -0,0 +1,311 @@
+package dctrack
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Credentials holds the username and password presented at login
+type Credentials struct {
+	Username string
+	Password string
+}
+
+// CredentialProvider supplies login credentials. It is called on every
+// login so rotated secrets are picked up without restarting the process.
+type CredentialProvider interface {
+	Credentials(ctx context.Context) (Credentials, error)
+}
+
+// CredentialProviderFunc adapts a plain function to CredentialProvider
+type CredentialProviderFunc func(ctx context.Context) (Credentials, error)
+
+// Credentials calls f
+func (f CredentialProviderFunc) Credentials(ctx context.Context) (Credentials, error) {
+	return f(ctx)
+}
+
+// StaticProvider returns the same credentials on every call
+type StaticProvider struct {
+	Username string
+	Password string
+}
+
+// NewStaticProvider creates a provider for fixed credentials
+func NewStaticProvider(username, password string) *StaticProvider {
+	return &StaticProvider{Username: username, Password: password}
+}
+
+// Credentials returns the fixed credentials
+func (p *StaticProvider) Credentials(ctx context.Context) (Credentials, error) {
+	return Credentials{Username: p.Username, Password: p.Password}, nil
+}
+
+// PasswordFileProvider reads the password from a file such as the one
+// referenced by password_file in config.yaml
+type PasswordFileProvider struct {
+	Username string
+	Path     string // A leading ~ is expanded to the user's home directory
+
+	// AllowInsecurePermissions skips the check that the file is not
+	// readable by group or others
+	AllowInsecurePermissions bool
+}
+
+// NewPasswordFileProvider creates a provider reading the password from path
+func NewPasswordFileProvider(username, path string) *PasswordFileProvider {
+	return &PasswordFileProvider{Username: username, Path: path}
+}
+
+// Credentials reads the password file, trimming surrounding whitespace
+func (p *PasswordFileProvider) Credentials(ctx context.Context) (Credentials, error) {
+	path, err := ExpandHome(p.Path)
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("error reading password file: %w", err)
+	}
+	if !p.AllowInsecurePermissions && runtime.GOOS != "windows" && info.Mode().Perm()&0o077 != 0 {
+		return Credentials{}, fmt.Errorf("password file %s has permissions %04o, expected 0600 or stricter",
+			path, info.Mode().Perm())
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("error reading password file: %w", err)
+	}
+
+	password := strings.TrimSpace(string(content))
+	if password == "" {
+		return Credentials{}, fmt.Errorf("password file %s is empty", path)
+	}
+
+	return Credentials{Username: p.Username, Password: password}, nil
+}
+
+// EnvProvider reads credentials from environment variables
+type EnvProvider struct {
+	UsernameVar string // Default: DCTRACK_USERNAME
+	PasswordVar string // Default: DCTRACK_PASSWORD
+}
+
+// NewEnvProvider creates a provider reading DCTRACK_USERNAME and DCTRACK_PASSWORD
+func NewEnvProvider() *EnvProvider {
+	return &EnvProvider{UsernameVar: "DCTRACK_USERNAME", PasswordVar: "DCTRACK_PASSWORD"}
+}
+
+// Credentials reads the configured environment variables
+func (p *EnvProvider) Credentials(ctx context.Context) (Credentials, error) {
+	usernameVar := p.UsernameVar
+	if usernameVar == "" {
+		usernameVar = "DCTRACK_USERNAME"
+	}
+	passwordVar := p.PasswordVar
+	if passwordVar == "" {
+		passwordVar = "DCTRACK_PASSWORD"
+	}
+
+	password := os.Getenv(passwordVar)
+	if password == "" {
+		return Credentials{}, fmt.Errorf("%s environment variable is not set", passwordVar)
+	}
+
+	return Credentials{Username: os.Getenv(usernameVar), Password: password}, nil
+}
+
+// CommandProvider runs an external credential helper using the
+// git-credential protocol: key=value lines describing the request are
+// written to stdin and username/password lines are read from stdout
+type CommandProvider struct {
+	Command  string
+	Args     []string
+	Host     string // Sent as host= so one helper can serve several instances
+	Username string // Sent as username= and used when the helper omits it
+}
+
+// NewCommandProvider creates a provider running command with args
+func NewCommandProvider(command string, args ...string) *CommandProvider {
+	return &CommandProvider{Command: command, Args: args}
+}
+
+// Credentials runs the helper and parses its output
+func (p *CommandProvider) Credentials(ctx context.Context) (Credentials, error) {
+	var input bytes.Buffer
+	input.WriteString("protocol=https\n")
+	if p.Host != "" {
+		fmt.Fprintf(&input, "host=%s\n", p.Host)
+	}
+	if p.Username != "" {
+		fmt.Fprintf(&input, "username=%s\n", p.Username)
+	}
+	input.WriteString("\n")
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, p.Command, p.Args...)
+	cmd.Stdin = &input
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return Credentials{}, fmt.Errorf("credential helper %s failed: %w (%s)",
+			p.Command, err, strings.TrimSpace(stderr.String()))
+	}
+
+	creds := Credentials{Username: p.Username}
+	scanner := bufio.NewScanner(&stdout)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "username":
+			creds.Username = value
+		case "password":
+			creds.Password = value
+		}
+	}
+
+	if creds.Password == "" {
+		return Credentials{}, fmt.Errorf("credential helper %s returned no password", p.Command)
+	}
+
+	return creds, nil
+}
+
+// VaultProvider reads credentials from a Vault-compatible KV secret over HTTP.
+// Both KV v1 ({"data":{...}}) and KV v2 ({"data":{"data":{...}}}) responses are understood.
+type VaultProvider struct {
+	Address     string // Default: $VAULT_ADDR
+	Token       string // Default: $VAULT_TOKEN
+	Path        string // Secret path, e.g. "secret/data/dctrack"
+	UsernameKey string // Default: "username"
+	PasswordKey string // Default: "password"
+	HTTPClient  *http.Client
+}
+
+// NewVaultProvider creates a provider reading the secret at path
+func NewVaultProvider(address, token, path string) *VaultProvider {
+	return &VaultProvider{Address: address, Token: token, Path: path}
+}
+
+// Credentials fetches the secret and extracts the username and password keys
+func (p *VaultProvider) Credentials(ctx context.Context) (Credentials, error) {
+	address := p.Address
+	if address == "" {
+		address = os.Getenv("VAULT_ADDR")
+	}
+	token := p.Token
+	if token == "" {
+		token = os.Getenv("VAULT_TOKEN")
+	}
+	if address == "" || p.Path == "" {
+		return Credentials{}, fmt.Errorf("vault address and secret path are required")
+	}
+
+	usernameKey := p.UsernameKey
+	if usernameKey == "" {
+		usernameKey = "username"
+	}
+	passwordKey := p.PasswordKey
+	if passwordKey == "" {
+		passwordKey = "password"
+	}
+
+	httpClient := p.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	secretURL := fmt.Sprintf("%s/v1/%s", strings.TrimRight(address, "/"), strings.TrimLeft(p.Path, "/"))
+	req, err := http.NewRequestWithContext(ctx, "GET", secretURL, nil)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("error creating vault request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("error making vault request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Credentials{}, fmt.Errorf("vault request for %s failed with status %d", p.Path, resp.StatusCode)
+	}
+
+	var secret struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&secret); err != nil {
+		return Credentials{}, fmt.Errorf("error decoding vault response: %w", err)
+	}
+
+	data := secret.Data
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		data = nested
+	}
+
+	password, _ := data[passwordKey].(string)
+	if password == "" {
+		return Credentials{}, fmt.Errorf("vault secret %s has no %q key", p.Path, passwordKey)
+	}
+	username, _ := data[usernameKey].(string)
+
+	return Credentials{Username: username, Password: password}, nil
+}
+
+// ExpandHome replaces a leading ~ in path with the user's home directory
+func ExpandHome(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("error expanding %s: %w", path, err)
+	}
+
+	return filepath.Join(home, strings.TrimPrefix(path, "~")), nil
+}
+
+// credentials resolves the username and password for the next login.
+// An explicit provider wins over PasswordFile, which wins over Password.
+func (c *Client) credentials(ctx context.Context) (Credentials, error) {
+	provider := c.config.CredentialProvider
+	if provider == nil && c.config.PasswordFile != "" {
+		provider = NewPasswordFileProvider(c.config.Username, c.config.PasswordFile)
+	}
+	if provider == nil {
+		return Credentials{Username: c.config.Username, Password: c.config.Password}, nil
+	}
+
+	creds, err := provider.Credentials(ctx)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("error fetching credentials: %w", err)
+	}
+	if creds.Username == "" {
+		creds.Username = c.config.Username
+	}
+	if creds.Username == "" || creds.Password == "" {
+		return Credentials{}, fmt.Errorf("credential provider returned incomplete credentials")
+	}
+
+	return creds, nil
+}
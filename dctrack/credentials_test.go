@@ -0,0 +1,227 @@
+package dctrack
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestPasswordFileProvider(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	secretsDir := filepath.Join(home, ".secrets")
+	if err := os.MkdirAll(secretsDir, 0700); err != nil {
+		t.Fatalf("Failed to create secrets dir: %v", err)
+	}
+	passwordFile := filepath.Join(secretsDir, "dctrack_password.txt")
+	if err := os.WriteFile(passwordFile, []byte("secret-password-123\n"), 0600); err != nil {
+		t.Fatalf("Failed to write password file: %v", err)
+	}
+
+	t.Run("tilde expansion", func(t *testing.T) {
+		provider := NewPasswordFileProvider("api-user", "~/.secrets/dctrack_password.txt")
+		creds, err := provider.Credentials(context.Background())
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if creds.Username != "api-user" {
+			t.Errorf("Expected Username 'api-user', got '%s'", creds.Username)
+		}
+		if creds.Password != "secret-password-123" {
+			t.Errorf("Expected trimmed password, got '%s'", creds.Password)
+		}
+	})
+
+	t.Run("insecure permissions", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("Permission checks are skipped on Windows")
+		}
+
+		loose := filepath.Join(secretsDir, "loose.txt")
+		if err := os.WriteFile(loose, []byte("secret"), 0644); err != nil {
+			t.Fatalf("Failed to write password file: %v", err)
+		}
+
+		provider := NewPasswordFileProvider("api-user", loose)
+		if _, err := provider.Credentials(context.Background()); err == nil {
+			t.Errorf("Expected error for world-readable password file")
+		}
+
+		provider.AllowInsecurePermissions = true
+		if _, err := provider.Credentials(context.Background()); err != nil {
+			t.Errorf("Unexpected error with AllowInsecurePermissions: %v", err)
+		}
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		provider := NewPasswordFileProvider("api-user", filepath.Join(secretsDir, "missing.txt"))
+		if _, err := provider.Credentials(context.Background()); err == nil {
+			t.Errorf("Expected error for missing password file")
+		}
+	})
+}
+
+func TestEnvProvider(t *testing.T) {
+	t.Setenv("DCTRACK_USERNAME", "env-user")
+	t.Setenv("DCTRACK_PASSWORD", "env-pass")
+
+	creds, err := NewEnvProvider().Credentials(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if creds.Username != "env-user" || creds.Password != "env-pass" {
+		t.Errorf("Expected env-user/env-pass, got %s/%s", creds.Username, creds.Password)
+	}
+
+	t.Setenv("DCTRACK_PASSWORD", "")
+	if _, err := NewEnvProvider().Credentials(context.Background()); err == nil {
+		t.Errorf("Expected error when password variable is empty")
+	}
+}
+
+func TestCommandProvider(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Test helper requires a POSIX shell")
+	}
+
+	// The helper echoes back the host it was asked about to prove stdin is wired up
+	provider := NewCommandProvider("sh", "-c",
+		`while read line && [ -n "$line" ]; do case "$line" in host=*) host="${line#host=}";; esac; done; `+
+			`echo "username=helper-user"; echo "password=pw-for-$host"`)
+	provider.Host = "dctrack.example.com"
+
+	creds, err := provider.Credentials(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if creds.Username != "helper-user" {
+		t.Errorf("Expected Username 'helper-user', got '%s'", creds.Username)
+	}
+	if creds.Password != "pw-for-dctrack.example.com" {
+		t.Errorf("Expected Password 'pw-for-dctrack.example.com', got '%s'", creds.Password)
+	}
+
+	failing := NewCommandProvider("sh", "-c", "exit 1")
+	if _, err := failing.Credentials(context.Background()); err == nil {
+		t.Errorf("Expected error from failing helper")
+	}
+}
+
+func TestVaultProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "vault-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/secret/data/dctrack":
+			w.Write([]byte(`{"data":{"data":{"username":"vault-user","password":"vault-pass"},"metadata":{"version":3}}}`))
+		case "/v1/kv/dctrack":
+			w.Write([]byte(`{"data":{"user":"v1-user","pass":"v1-pass"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	t.Run("kv v2", func(t *testing.T) {
+		creds, err := NewVaultProvider(server.URL, "vault-token", "secret/data/dctrack").Credentials(context.Background())
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if creds.Username != "vault-user" || creds.Password != "vault-pass" {
+			t.Errorf("Expected vault-user/vault-pass, got %s/%s", creds.Username, creds.Password)
+		}
+	})
+
+	t.Run("kv v1 with custom keys", func(t *testing.T) {
+		provider := NewVaultProvider(server.URL, "vault-token", "kv/dctrack")
+		provider.UsernameKey = "user"
+		provider.PasswordKey = "pass"
+
+		creds, err := provider.Credentials(context.Background())
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if creds.Username != "v1-user" || creds.Password != "v1-pass" {
+			t.Errorf("Expected v1-user/v1-pass, got %s/%s", creds.Username, creds.Password)
+		}
+	})
+
+	t.Run("bad token", func(t *testing.T) {
+		if _, err := NewVaultProvider(server.URL, "wrong", "secret/data/dctrack").Credentials(context.Background()); err == nil {
+			t.Errorf("Expected error for rejected vault token")
+		}
+	})
+}
+
+func TestClientFetchesCredentialsAtLogin(t *testing.T) {
+	passwords := []string{"first-password", "rotated-password"}
+	calls := 0
+	provider := CredentialProviderFunc(func(ctx context.Context) (Credentials, error) {
+		password := passwords[calls]
+		calls++
+		return Credentials{Password: password}, nil
+	})
+
+	var seen []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, _ := r.BasicAuth()
+		seen = append(seen, username+":"+password)
+		w.Header().Set("Authorization", "Bearer token")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := New(server.URL+"/api/v2", "api-user", "", WithCredentialProvider(provider))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	// Nothing is fetched until the first login
+	if calls != 0 {
+		t.Errorf("Expected no credential fetch before login, got %d", calls)
+	}
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if err := client.login(ctx); err != nil {
+			t.Fatalf("Login failed: %v", err)
+		}
+	}
+
+	expected := []string{"api-user:first-password", "api-user:rotated-password"}
+	if len(seen) != len(expected) {
+		t.Fatalf("Expected %d logins, got %d", len(expected), len(seen))
+	}
+	for i := range expected {
+		if seen[i] != expected[i] {
+			t.Errorf("Expected login %d with %s, got %s", i, expected[i], seen[i])
+		}
+	}
+}
+
+func TestConfigValidationWithCredentialSources(t *testing.T) {
+	config := DefaultConfig("https://test.com/api/v2", "user", "")
+	if err := config.Validate(); err == nil {
+		t.Errorf("Expected error without password or credential source")
+	}
+
+	config.PasswordFile = "~/.secrets/dctrack_password.txt"
+	if err := config.Validate(); err != nil {
+		t.Errorf("Unexpected error with PasswordFile: %v", err)
+	}
+
+	config = DefaultConfig("https://test.com/api/v2", "", "")
+	config.CredentialProvider = NewEnvProvider()
+	if err := config.Validate(); err != nil {
+		t.Errorf("Unexpected error with CredentialProvider: %v", err)
+	}
+}
@@ -0,0 +1,290 @@
+package dctrack
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// customFieldPrefix is prepended to a custom field's name to form its
+// DCTrack column
+const customFieldPrefix = "tiCustomField_"
+
+// CustomFieldColumn returns the DCTrack column of a custom field, e.g.
+// "tiCustomField_Audit Date" for "Audit Date"
+func CustomFieldColumn(name string) string {
+	return customFieldPrefix + name
+}
+
+// CustomFieldValue is a custom field value as DCTrack returned it, with
+// typed accessors
+type CustomFieldValue struct {
+	raw interface{}
+}
+
+// NewCustomFieldValue wraps a raw value, e.g. for comparisons in tests
+func NewCustomFieldValue(raw interface{}) CustomFieldValue {
+	return CustomFieldValue{raw: raw}
+}
+
+// Raw returns the value as decoded from JSON
+func (v CustomFieldValue) Raw() interface{} {
+	return v.raw
+}
+
+// IsZero reports a missing, null or empty value
+func (v CustomFieldValue) IsZero() bool {
+	return v.raw == nil || v.String() == ""
+}
+
+// String returns the value as text, "" when it is null
+func (v CustomFieldValue) String() string {
+	if v.raw == nil {
+		return ""
+	}
+	return formatValue(v.raw)
+}
+
+// Float parses the value as a number
+func (v CustomFieldValue) Float() (float64, error) {
+	f, err := strconv.ParseFloat(strings.TrimSpace(v.String()), 64)
+	if err != nil {
+		return 0, fmt.Errorf("custom field value %q is not a number", v.String())
+	}
+	return f, nil
+}
+
+// Time parses the value as a DCTrack date or timestamp
+func (v CustomFieldValue) Time() (time.Time, error) {
+	return parseTime(strings.TrimSpace(v.String()))
+}
+
+// Bool parses the value as a checkbox, accepting true/false, yes/no and 1/0
+func (v CustomFieldValue) Bool() (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(v.String())) {
+	case "true", "yes", "1":
+		return true, nil
+	case "false", "no", "0", "":
+		return false, nil
+	}
+	return false, fmt.Errorf("custom field value %q is not a boolean", v.String())
+}
+
+// MarshalJSON encodes the raw value
+func (v CustomFieldValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.raw)
+}
+
+// UnmarshalJSON decodes a raw value
+func (v *CustomFieldValue) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &v.raw)
+}
+
+// CustomField returns the named custom field of an item. Names are matched
+// exactly, then case-insensitively.
+func (item DCTrackItem) CustomField(name string) (CustomFieldValue, bool) {
+	if value, ok := item.CustomFields[name]; ok {
+		return value, true
+	}
+	for key, value := range item.CustomFields {
+		if strings.EqualFold(key, name) {
+			return value, true
+		}
+	}
+	return CustomFieldValue{}, false
+}
+
+// CustomFieldType is the data type of a custom field definition
+type CustomFieldType string
+
+const (
+	CustomFieldText     CustomFieldType = "Text"
+	CustomFieldNumber   CustomFieldType = "Number"
+	CustomFieldDate     CustomFieldType = "Date"
+	CustomFieldPicklist CustomFieldType = "Picklist"
+	CustomFieldCheckbox CustomFieldType = "Checkbox"
+)
+
+// CustomFieldDefinition describes one of the tenant's custom fields
+type CustomFieldDefinition struct {
+	Name           string          `json:"name"`
+	Type           CustomFieldType `json:"type"`
+	Required       bool            `json:"required"`
+	PicklistValues []string        `json:"picklist_values,omitempty"`
+}
+
+// Column returns the DCTrack column the field is read and written under
+func (d CustomFieldDefinition) Column() string {
+	return CustomFieldColumn(d.Name)
+}
+
+// Validate checks that value suits the field's type and picklist
+func (d CustomFieldDefinition) Validate(value interface{}) error {
+	if value == nil {
+		if d.Required {
+			return fmt.Errorf("is required")
+		}
+		return nil
+	}
+	if _, ok := value.(time.Time); ok {
+		if d.Type != CustomFieldDate {
+			return fmt.Errorf("is a %s field, not a date", d.Type)
+		}
+		return nil
+	}
+
+	text := NewCustomFieldValue(value)
+	if text.String() == "" {
+		if d.Required {
+			return fmt.Errorf("is required")
+		}
+		return nil
+	}
+
+	switch d.Type {
+	case CustomFieldNumber:
+		if _, err := text.Float(); err != nil {
+			return fmt.Errorf("must be a number")
+		}
+	case CustomFieldDate:
+		if _, err := text.Time(); err != nil {
+			return fmt.Errorf("must be a date")
+		}
+	case CustomFieldCheckbox:
+		if _, err := text.Bool(); err != nil {
+			return fmt.Errorf("must be true or false")
+		}
+	case CustomFieldPicklist:
+		for _, allowed := range d.PicklistValues {
+			if strings.EqualFold(allowed, text.String()) {
+				return nil
+			}
+		}
+		return fmt.Errorf("must be one of %s", strings.Join(d.PicklistValues, ", "))
+	}
+	return nil
+}
+
+// ListCustomFields retrieves the tenant's custom field definitions
+func (c *Client) ListCustomFields(ctx context.Context) ([]CustomFieldDefinition, error) {
+	records, err := c.getRecordList(ctx, "customfields", c.config.URL+"/settings/customFields",
+		"customFields", "fields")
+	if err != nil {
+		return nil, fmt.Errorf("error listing custom fields: %w", err)
+	}
+
+	definitions := make([]CustomFieldDefinition, 0, len(records))
+	for _, record := range records {
+		definitions = append(definitions, customFieldFromRecord(record))
+	}
+
+	c.logger.Info("Fetched custom field definitions from DCTrack", zap.Int("count", len(definitions)))
+	return definitions, nil
+}
+
+// SetCustomFields updates custom fields of an item, keyed by field name.
+// Values are checked against the tenant's definitions first, and unknown
+// names are rejected; servers without the definitions endpoint skip the
+// check. Time values are sent as dates.
+func (c *Client) SetCustomFields(ctx context.Context, id string, values map[string]interface{}) (*DCTrackItem, error) {
+	definitions, err := c.ListCustomFields(ctx)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+	if err == nil {
+		if err := ValidateCustomFields(definitions, values); err != nil {
+			return nil, err
+		}
+		values = canonicalCustomFields(definitions, values)
+	}
+
+	return c.UpdateItem(ctx, id, ItemInput{CustomFields: values})
+}
+
+// ValidateCustomFields checks values keyed by field name against
+// definitions, reporting every problem by DCTrack column
+func ValidateCustomFields(definitions []CustomFieldDefinition, values map[string]interface{}) error {
+	byName := make(map[string]CustomFieldDefinition, len(definitions))
+	for _, definition := range definitions {
+		byName[strings.ToLower(definition.Name)] = definition
+	}
+
+	var problems []ErrorDetail
+	for name, value := range values {
+		definition, ok := byName[strings.ToLower(name)]
+		if !ok {
+			problems = append(problems, ErrorDetail{Field: CustomFieldColumn(name), Message: "is not a custom field"})
+			continue
+		}
+		if err := definition.Validate(value); err != nil {
+			problems = append(problems, ErrorDetail{Field: definition.Column(), Message: err.Error()})
+		}
+	}
+
+	if len(problems) > 0 {
+		sort.Slice(problems, func(i, j int) bool { return problems[i].Field < problems[j].Field })
+		return &ValidationError{Errors: problems}
+	}
+	return nil
+}
+
+// canonicalCustomFields rekeys values by the defined spelling of each
+// field's name, since DCTrack columns are case-sensitive
+func canonicalCustomFields(definitions []CustomFieldDefinition, values map[string]interface{}) map[string]interface{} {
+	canonical := make(map[string]interface{}, len(values))
+	for name, value := range values {
+		for _, definition := range definitions {
+			if strings.EqualFold(definition.Name, name) {
+				name = definition.Name
+				break
+			}
+		}
+		canonical[name] = value
+	}
+	return canonical
+}
+
+// customFieldFromRecord maps a custom field definition record
+func customFieldFromRecord(record map[string]interface{}) CustomFieldDefinition {
+	definition := CustomFieldDefinition{
+		Name: strings.TrimPrefix(recordString(record, "name"), customFieldPrefix),
+		Type: customFieldType(recordString(record, "type")),
+	}
+	definition.Required, _ = NewCustomFieldValue(record["required"]).Bool()
+
+	entries, _ := record["picklistValues"].([]interface{})
+	for _, entry := range entries {
+		if object, ok := entry.(map[string]interface{}); ok {
+			entry = recordString(object, "value")
+		}
+		if text := formatValue(entry); text != "" {
+			definition.PicklistValues = append(definition.PicklistValues, text)
+		}
+	}
+	return definition
+}
+
+// customFieldType normalizes the type names used by different DCTrack
+// versions, keeping unknown ones as given
+func customFieldType(name string) CustomFieldType {
+	switch strings.ToLower(name) {
+	case "text", "string":
+		return CustomFieldText
+	case "number", "numeric":
+		return CustomFieldNumber
+	case "date":
+		return CustomFieldDate
+	case "picklist", "list", "dropdown":
+		return CustomFieldPicklist
+	case "checkbox", "boolean":
+		return CustomFieldCheckbox
+	}
+	return CustomFieldType(name)
+}
@@ -0,0 +1,162 @@
+package dctrack
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestCustomFieldMapping(t *testing.T) {
+	client := NewClient(DefaultConfig("https://dctrack.example.com/api/v2", "user", "pass"), zap.NewNop())
+
+	item, err := client.mapDCTrackRecord(map[string]interface{}{
+		"id":                           "1",
+		"tiCustomField_Audit Date":     "2024-05-01",
+		"tiCustomField_Rack Budget":    1250.5,
+		"tiCustomField_Decommissioned": "Yes",
+		"tiCustomField_Cost Center":    "CC-42",
+		"tiCustomField_Empty":          nil,
+	})
+	if err != nil {
+		t.Fatalf("mapDCTrackRecord failed: %v", err)
+	}
+
+	if len(item.CustomFields) != 4 {
+		t.Errorf("Expected 4 custom fields, got %v", item.CustomFields)
+	}
+
+	audit, ok := item.CustomField("audit date")
+	if !ok {
+		t.Fatalf("Expected case-insensitive lookup of Audit Date")
+	}
+	if date, err := audit.Time(); err != nil || !date.Equal(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected 2024-05-01, got %v (%v)", date, err)
+	}
+	if budget, err := item.CustomFields["Rack Budget"].Float(); err != nil || budget != 1250.5 {
+		t.Errorf("Expected budget 1250.5, got %v (%v)", budget, err)
+	}
+	if decommissioned, err := item.CustomFields["Decommissioned"].Bool(); err != nil || !decommissioned {
+		t.Errorf("Expected decommissioned, got %v (%v)", decommissioned, err)
+	}
+	if _, err := item.CustomFields["Cost Center"].Float(); err == nil {
+		t.Errorf("Expected error reading text as a number")
+	}
+	if _, ok := item.CustomField("Missing"); ok {
+		t.Errorf("Expected no value for an unknown field")
+	}
+
+	encoded, err := json.Marshal(item.CustomFields)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var decoded map[string]CustomFieldValue
+	if err := json.Unmarshal(encoded, &decoded); err != nil || decoded["Rack Budget"].String() != "1250.5" {
+		t.Errorf("Expected custom fields to round-trip through JSON, got %s", encoded)
+	}
+}
+
+func TestWithCustomFields(t *testing.T) {
+	tests := []struct {
+		name      string
+		allFields bool
+		expected  []string
+	}{
+		{"minimal set", false, []string{"tiCustomField_Cost Center", "tiCustomField_Primary Contact"}},
+		{"full set already has primary contact", true, []string{"tiCustomField_Cost Center"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig("https://dctrack.example.com/api/v2", "user", "pass")
+			config.RequestAllFields = tt.allFields
+			WithCustomFields("Cost Center", "Primary Contact")(&config)
+			client := NewClient(config, zap.NewNop())
+
+			before := len(client.standardFieldsPayload()["selectedColumns"].([]map[string]string))
+			columns := client.buildStandardFieldsPayload()["selectedColumns"].([]map[string]string)
+
+			var added []string
+			for _, column := range columns[before:] {
+				added = append(added, column["name"])
+			}
+			if !reflect.DeepEqual(added, tt.expected) {
+				t.Errorf("Expected added columns %v, got %v", tt.expected, added)
+			}
+		})
+	}
+}
+
+func TestSetCustomFields(t *testing.T) {
+	store := &itemStore{items: map[string]map[string]interface{}{
+		"5": {"id": "5", "tiName": "web-01", "tiClass": "Device", "cmbLocation": "RDU2"},
+	}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/authentication/login":
+			w.Header().Set("Authorization", "Bearer mock-token-12345")
+		case "/api/v2/settings/customFields":
+			w.Write([]byte(`{"customFields":[
+				{"name":"Audit Date","type":"DATE"},
+				{"name":"Rack Budget","type":"numeric"},
+				{"name":"Tier","type":"List","required":true,"picklistValues":[{"value":"Gold"},{"value":"Silver"}]}
+			]}`))
+		default:
+			store.serve(t, w, r)
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(server.URL+"/api/v2", "testuser", "testpass")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx := context.Background()
+
+	definitions, err := client.ListCustomFields(ctx)
+	if err != nil {
+		t.Fatalf("ListCustomFields failed: %v", err)
+	}
+	expected := CustomFieldDefinition{Name: "Tier", Type: CustomFieldPicklist, Required: true, PicklistValues: []string{"Gold", "Silver"}}
+	if len(definitions) != 3 || definitions[0].Type != CustomFieldDate || definitions[1].Type != CustomFieldNumber ||
+		!reflect.DeepEqual(definitions[2], expected) {
+		t.Errorf("Unexpected definitions %+v", definitions)
+	}
+
+	_, err = client.SetCustomFields(ctx, "5", map[string]interface{}{
+		"Rack Budget": "lots",
+		"Tier":        "Bronze",
+		"Owner":       "ops",
+	})
+	expectedErrors := []ErrorDetail{
+		{Field: "tiCustomField_Owner", Message: "is not a custom field"},
+		{Field: "tiCustomField_Rack Budget", Message: "must be a number"},
+		{Field: "tiCustomField_Tier", Message: "must be one of Gold, Silver"},
+	}
+	if !errors.Is(err, ErrValidation) || !reflect.DeepEqual(FieldErrors(err), expectedErrors) {
+		t.Errorf("Expected %v, got %v", expectedErrors, err)
+	}
+	if store.writes != 0 {
+		t.Errorf("Expected no write for invalid values, got %d", store.writes)
+	}
+
+	item, err := client.SetCustomFields(ctx, "5", map[string]interface{}{
+		"audit date": time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC),
+		"Tier":       "gold",
+	})
+	if err != nil {
+		t.Fatalf("SetCustomFields failed: %v", err)
+	}
+	if got := store.items["5"]["tiCustomField_Audit Date"]; got != "2024-06-30" {
+		t.Errorf("Expected the date written under the defined name, got %v", got)
+	}
+	if tier, _ := item.CustomField("Tier"); tier.String() != "gold" {
+		t.Errorf("Expected the updated item back, got %v", item.CustomFields)
+	}
+}
@@ -0,0 +1,169 @@
+package dctrack
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors for use with errors.Is. An *APIError matches the sentinel
+// for its status code.
+var (
+	// ErrNotFound reports a missing item or endpoint (HTTP 404)
+	ErrNotFound = errors.New("not found")
+	// ErrUnauthorized reports rejected credentials or a token lacking
+	// permission (HTTP 401 and 403)
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrRateLimited reports throttling by DCTrack (HTTP 429)
+	ErrRateLimited = errors.New("rate limited")
+	// ErrMappingFailed reports a record that could not be converted to a DCTrackItem
+	ErrMappingFailed = errors.New("record mapping failed")
+	// ErrValidation reports item fields rejected locally or by DCTrack
+	// (HTTP 400 and 422)
+	ErrValidation = errors.New("validation failed")
+)
+
+// maxErrorBody bounds how much of an error response is kept
+const maxErrorBody = 64 << 10
+
+// APIError is returned when DCTrack answers with an unexpected status
+type APIError struct {
+	StatusCode int
+	Method     string
+	Endpoint   string     // Request path, e.g. "/api/v2/quicksearch/items"
+	RequestID  string     // Server-assigned request ID, if any, for support tickets
+	Body       *ErrorBody // Decoded DCTrack error document, nil if the body was not JSON
+	RawBody    string     // Response body as received, truncated to 64 KiB
+}
+
+// ErrorBody is the JSON error document DCTrack returns with failed requests
+type ErrorBody struct {
+	Error   string        `json:"error"`
+	Message string        `json:"message"`
+	Errors  []ErrorDetail `json:"errors"`
+}
+
+// ErrorDetail describes one problem reported in an ErrorBody
+type ErrorDetail struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (d ErrorDetail) String() string {
+	if d.Field == "" {
+		return d.Message
+	}
+	return d.Field + " " + d.Message
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("%s %s failed with status %d", e.Method, e.Endpoint, e.StatusCode)
+	if detail := e.Message(); detail != "" {
+		msg += ": " + detail
+	}
+	if e.RequestID != "" {
+		msg += fmt.Sprintf(" (request ID %s)", e.RequestID)
+	}
+	return msg
+}
+
+// Is matches the sentinel errors for the status code
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrValidation:
+		return e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusUnprocessableEntity
+	}
+	return false
+}
+
+// Message returns the most specific message DCTrack gave, or ""
+func (e *APIError) Message() string {
+	if e.Body == nil {
+		return ""
+	}
+	if e.Body.Message != "" {
+		return e.Body.Message
+	}
+	if e.Body.Error != "" {
+		return e.Body.Error
+	}
+	for _, detail := range e.Body.Errors {
+		if detail.Message != "" {
+			return detail.Message
+		}
+	}
+	return ""
+}
+
+// ValidationError is returned when an ItemInput fails local checks before
+// anything is sent to DCTrack
+type ValidationError struct {
+	Errors []ErrorDetail
+}
+
+func (e *ValidationError) Error() string {
+	problems := make([]string, len(e.Errors))
+	for i, detail := range e.Errors {
+		problems[i] = detail.String()
+	}
+	return "invalid item: " + strings.Join(problems, "; ")
+}
+
+// Is matches ErrValidation
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// FieldErrors returns the per-field problems carried by err, whether found
+// locally (*ValidationError) or reported by DCTrack (*APIError)
+func FieldErrors(err error) []ErrorDetail {
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		return validationErr.Errors
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.Body != nil {
+		return apiErr.Body.Errors
+	}
+	return nil
+}
+
+// newAPIError builds an *APIError from a failed response, consuming its body
+func newAPIError(resp *http.Response) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		RequestID:  firstHeader(resp.Header, "X-Request-Id", "X-Correlation-Id", "Request-Id"),
+	}
+	if resp.Request != nil {
+		apiErr.Method = resp.Request.Method
+		apiErr.Endpoint = resp.Request.URL.Path
+	}
+
+	raw, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	apiErr.RawBody = string(raw)
+
+	var body ErrorBody
+	if err := json.Unmarshal(raw, &body); err == nil {
+		apiErr.Body = &body
+	}
+
+	return apiErr
+}
+
+func firstHeader(header http.Header, names ...string) string {
+	for _, name := range names {
+		if value := strings.TrimSpace(header.Get(name)); value != "" {
+			return value
+		}
+	}
+	return ""
+}
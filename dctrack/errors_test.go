@@ -0,0 +1,136 @@
+package dctrack
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAPIErrorSentinels(t *testing.T) {
+	tests := []struct {
+		status   int
+		sentinel error
+		matches  bool
+	}{
+		{http.StatusNotFound, ErrNotFound, true},
+		{http.StatusUnauthorized, ErrUnauthorized, true},
+		{http.StatusForbidden, ErrUnauthorized, true},
+		{http.StatusTooManyRequests, ErrRateLimited, true},
+		{http.StatusInternalServerError, ErrNotFound, false},
+		{http.StatusNotFound, ErrUnauthorized, false},
+		{http.StatusBadRequest, ErrRateLimited, false},
+	}
+
+	for _, tt := range tests {
+		err := error(&APIError{StatusCode: tt.status})
+		if errors.Is(err, tt.sentinel) != tt.matches {
+			t.Errorf("Status %d: expected errors.Is(%v) to be %t", tt.status, tt.sentinel, tt.matches)
+		}
+	}
+}
+
+func TestFetchPageReturnsAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v2/authentication/login" {
+			w.Header().Set("Authorization", "Bearer mock-token-12345")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Request-Id", "req-42")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":"Bad Request","message":"Invalid column cmbBogus","errors":[{"field":"selectedColumns","message":"unknown column"}]}`))
+	}))
+	defer server.Close()
+
+	client, err := New(server.URL+"/api/v2", "testuser", "testpass")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	_, err = client.GetItems(context.Background())
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected *APIError, got %v", err)
+	}
+	if apiErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", apiErr.StatusCode)
+	}
+	if apiErr.Method != "POST" || apiErr.Endpoint != "/api/v2/quicksearch/items" {
+		t.Errorf("Unexpected request: %s %s", apiErr.Method, apiErr.Endpoint)
+	}
+	if apiErr.RequestID != "req-42" {
+		t.Errorf("Expected request ID req-42, got %s", apiErr.RequestID)
+	}
+	if apiErr.Body == nil || len(apiErr.Body.Errors) != 1 || apiErr.Body.Errors[0].Field != "selectedColumns" {
+		t.Errorf("Expected decoded error body, got %+v", apiErr.Body)
+	}
+	if apiErr.Message() != "Invalid column cmbBogus" {
+		t.Errorf("Expected message from body, got %s", apiErr.Message())
+	}
+	if !strings.Contains(err.Error(), "status 400") || !strings.Contains(err.Error(), "req-42") {
+		t.Errorf("Expected status and request ID in message, got %s", err.Error())
+	}
+}
+
+func TestAPIErrorWithoutJSONBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("<html>Forbidden</html>"))
+	}))
+	defer server.Close()
+
+	client, err := New(server.URL+"/api/v2", "testuser", "testpass")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	_, err = client.GetItems(context.Background())
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("Expected ErrUnauthorized from login, got %v", err)
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected *APIError, got %v", err)
+	}
+	if apiErr.Body != nil {
+		t.Errorf("Expected no decoded body, got %+v", apiErr.Body)
+	}
+	if apiErr.RawBody != "<html>Forbidden</html>" {
+		t.Errorf("Expected raw body, got %s", apiErr.RawBody)
+	}
+	if apiErr.Endpoint != "/api/v2/authentication/login" {
+		t.Errorf("Expected login endpoint, got %s", apiErr.Endpoint)
+	}
+}
+
+func TestErrorsFromLookupAndMapping(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v2/authentication/login" {
+			w.Header().Set("Authorization", "Bearer mock-token-12345")
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":"Not Found","message":"Item 12345 does not exist"}`))
+	}))
+	defer server.Close()
+
+	client, err := New(server.URL+"/api/v2", "testuser", "testpass")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	if _, err := client.GetItemByID(context.Background(), "12345"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+
+	client.config.StrictMapping = true
+	_, err = client.mapDCTrackRecord(map[string]interface{}{"id": "1"})
+	if !errors.Is(err, ErrMappingFailed) {
+		t.Errorf("Expected ErrMappingFailed, got %v", err)
+	}
+}
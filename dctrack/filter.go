@@ -0,0 +1,673 @@
+package dctrack
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Filter is a node of a filter expression over items, built with And, Or,
+// Not and the condition constructors, or parsed with ParseFilter:
+//
+//	filter := dctrack.And(
+//		dctrack.In("make", "Dell", "HPE"),
+//		dctrack.Gt("power", 500),
+//		dctrack.Before("contractEndDate", time.Now().AddDate(0, 6, 0)),
+//	)
+//	items, err := client.GetItemsWhere(ctx, dctrack.InstalledOnly(), filter)
+type Filter interface {
+	// Match evaluates the filter against an item
+	Match(item DCTrackItem) bool
+	// String returns the filter in the syntax ParseFilter reads
+	String() string
+}
+
+// Operator is the comparison of a condition
+type Operator string
+
+const (
+	OpEq       Operator = "="
+	OpNe       Operator = "!="
+	OpGt       Operator = ">"
+	OpGe       Operator = ">="
+	OpLt       Operator = "<"
+	OpLe       Operator = "<="
+	OpBetween  Operator = "between"
+	OpIn       Operator = "in"
+	OpContains Operator = "contains"
+	OpIsNull   Operator = "is null"
+)
+
+// Condition compares one field of an item with values. Field is a name
+// such as "make" or "power", or a DCTrack column such as "cmbMake" or
+// "tiCustomField_Audit Date".
+type Condition struct {
+	Field    string
+	Operator Operator
+	Values   []interface{}
+}
+
+// Eq matches items whose field equals value
+func Eq(field string, value interface{}) *Condition {
+	return &Condition{Field: field, Operator: OpEq, Values: []interface{}{value}}
+}
+
+// Ne matches items whose field differs from value or is empty
+func Ne(field string, value interface{}) *Condition {
+	return &Condition{Field: field, Operator: OpNe, Values: []interface{}{value}}
+}
+
+// Gt matches items whose field is greater than value
+func Gt(field string, value interface{}) *Condition {
+	return &Condition{Field: field, Operator: OpGt, Values: []interface{}{value}}
+}
+
+// Ge matches items whose field is greater than or equal to value
+func Ge(field string, value interface{}) *Condition {
+	return &Condition{Field: field, Operator: OpGe, Values: []interface{}{value}}
+}
+
+// Lt matches items whose field is less than value
+func Lt(field string, value interface{}) *Condition {
+	return &Condition{Field: field, Operator: OpLt, Values: []interface{}{value}}
+}
+
+// Le matches items whose field is less than or equal to value
+func Le(field string, value interface{}) *Condition {
+	return &Condition{Field: field, Operator: OpLe, Values: []interface{}{value}}
+}
+
+// Between matches items whose field lies in [low, high]
+func Between(field string, low, high interface{}) *Condition {
+	return &Condition{Field: field, Operator: OpBetween, Values: []interface{}{low, high}}
+}
+
+// In matches items whose field equals any of values
+func In(field string, values ...interface{}) *Condition {
+	return &Condition{Field: field, Operator: OpIn, Values: values}
+}
+
+// Contains matches items whose field contains text, ignoring case
+func Contains(field, text string) *Condition {
+	return &Condition{Field: field, Operator: OpContains, Values: []interface{}{text}}
+}
+
+// IsNull matches items whose field is empty. Numbers DCTrack leaves unset
+// decode as zero, so zero counts as empty.
+func IsNull(field string) *Condition {
+	return &Condition{Field: field, Operator: OpIsNull}
+}
+
+// Before matches items whose date field is before t
+func Before(field string, t time.Time) *Condition {
+	return Lt(field, t)
+}
+
+// After matches items whose date field is after t
+func After(field string, t time.Time) *Condition {
+	return Gt(field, t)
+}
+
+// Match evaluates the condition. Apart from Ne and IsNull, conditions on
+// an empty field never match, and neither do conditions missing values.
+func (c *Condition) Match(item DCTrackItem) bool {
+	field, ok := lookupFilterField(c.Field)
+	if !ok || len(c.Values) < c.Operator.minValues() {
+		return false
+	}
+
+	value := field.value(item)
+	if c.Operator == OpIsNull {
+		return isNullValue(value)
+	}
+	if isNullValue(value) {
+		return c.Operator == OpNe
+	}
+
+	switch c.Operator {
+	case OpEq:
+		return compareValues(field.kind, value, c.Values[0]) == 0
+	case OpNe:
+		return compareValues(field.kind, value, c.Values[0]) != 0
+	case OpGt:
+		return compareValues(field.kind, value, c.Values[0]) > 0
+	case OpGe:
+		return compareValues(field.kind, value, c.Values[0]) >= 0
+	case OpLt:
+		return compareValues(field.kind, value, c.Values[0]) < 0
+	case OpLe:
+		return compareValues(field.kind, value, c.Values[0]) <= 0
+	case OpBetween:
+		return compareValues(field.kind, value, c.Values[0]) >= 0 && compareValues(field.kind, value, c.Values[1]) <= 0
+	case OpIn:
+		for _, candidate := range c.Values {
+			if compareValues(field.kind, value, candidate) == 0 {
+				return true
+			}
+		}
+		return false
+	case OpContains:
+		return strings.Contains(strings.ToLower(filterText(value)), strings.ToLower(filterText(c.Values[0])))
+	}
+	return false
+}
+
+// minValues is the number of values an operator needs at least
+func (op Operator) minValues() int {
+	switch op {
+	case OpIsNull:
+		return 0
+	case OpBetween:
+		return 2
+	}
+	return 1
+}
+
+func (c *Condition) String() string {
+	field := quoteFilterWord(c.Field)
+	switch c.Operator {
+	case OpIsNull:
+		return field + " is null"
+	case OpBetween:
+		if len(c.Values) < 2 {
+			break
+		}
+		return fmt.Sprintf("%s between %s and %s", field, quoteFilterValue(c.Values[0]), quoteFilterValue(c.Values[1]))
+	case OpIn:
+		values := make([]string, len(c.Values))
+		for i, value := range c.Values {
+			values[i] = quoteFilterValue(value)
+		}
+		return fmt.Sprintf("%s in (%s)", field, strings.Join(values, ", "))
+	}
+	if len(c.Values) == 0 {
+		return fmt.Sprintf("%s %s", field, c.Operator)
+	}
+	return fmt.Sprintf("%s %s %s", field, c.Operator, quoteFilterValue(c.Values[0]))
+}
+
+// validate checks the field and the number and types of the values
+func (c *Condition) validate() error {
+	field, ok := lookupFilterField(c.Field)
+	if !ok {
+		return fmt.Errorf("unknown filter field %q", c.Field)
+	}
+
+	expected := 1
+	switch c.Operator {
+	case OpIsNull:
+		expected = 0
+	case OpBetween:
+		expected = 2
+	case OpIn:
+		if len(c.Values) == 0 {
+			return fmt.Errorf("%s: in needs at least one value", c.Field)
+		}
+		expected = len(c.Values)
+	case OpEq, OpNe, OpGt, OpGe, OpLt, OpLe, OpContains:
+	default:
+		return fmt.Errorf("%s: unknown operator %q", c.Field, c.Operator)
+	}
+	if len(c.Values) != expected {
+		return fmt.Errorf("%s %s: expected %d values, got %d", c.Field, c.Operator, expected, len(c.Values))
+	}
+
+	for _, value := range c.Values {
+		switch field.kind {
+		case fieldNumber:
+			if _, ok := filterNumber(value); !ok {
+				return fmt.Errorf("%s: %q is not a number", c.Field, filterText(value))
+			}
+		case fieldDate:
+			if _, ok := filterDate(value); !ok {
+				return fmt.Errorf("%s: %q is not a date", c.Field, filterText(value))
+			}
+		}
+	}
+	return nil
+}
+
+type andFilter struct {
+	terms []Filter
+}
+
+// And matches items that match every filter
+func And(filters ...Filter) Filter {
+	return &andFilter{terms: filters}
+}
+
+func (f *andFilter) Match(item DCTrackItem) bool {
+	for _, term := range f.terms {
+		if !term.Match(item) {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *andFilter) String() string {
+	return joinFilters(f.terms, " and ", func(term Filter) bool {
+		_, isOr := term.(*orFilter)
+		return isOr
+	})
+}
+
+type orFilter struct {
+	terms []Filter
+}
+
+// Or matches items that match any filter
+func Or(filters ...Filter) Filter {
+	return &orFilter{terms: filters}
+}
+
+func (f *orFilter) Match(item DCTrackItem) bool {
+	for _, term := range f.terms {
+		if term.Match(item) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *orFilter) String() string {
+	return joinFilters(f.terms, " or ", func(Filter) bool { return false })
+}
+
+type notFilter struct {
+	term Filter
+}
+
+// Not matches items that do not match filter
+func Not(filter Filter) Filter {
+	return &notFilter{term: filter}
+}
+
+func (f *notFilter) Match(item DCTrackItem) bool {
+	return !f.term.Match(item)
+}
+
+func (f *notFilter) String() string {
+	if _, ok := f.term.(*Condition); ok {
+		return "not " + f.term.String()
+	}
+	return "not (" + f.term.String() + ")"
+}
+
+// joinFilters renders terms, parenthesizing those paren selects
+func joinFilters(terms []Filter, separator string, paren func(Filter) bool) string {
+	parts := make([]string, len(terms))
+	for i, term := range terms {
+		parts[i] = term.String()
+		if paren(term) {
+			parts[i] = "(" + parts[i] + ")"
+		}
+	}
+	return strings.Join(parts, separator)
+}
+
+// FilterPlan is a compiled filter: the conditions sent to DCTrack as
+// quicksearch column filters, and the part evaluated by the client
+type FilterPlan struct {
+	Pushed   []*Condition // Sent to DCTrack; also checked by the client
+	Residual Filter       // Evaluated by the client only, nil when everything is pushed
+	Columns  []string     // Columns the filter reads
+}
+
+// String describes which parts of the filter were pushed down
+func (p *FilterPlan) String() string {
+	pushed := make([]string, len(p.Pushed))
+	for i, condition := range p.Pushed {
+		pushed[i] = condition.String()
+	}
+
+	var parts []string
+	if len(pushed) > 0 {
+		parts = append(parts, "pushed down: "+strings.Join(pushed, " and "))
+	}
+	if p.Residual != nil {
+		parts = append(parts, "client-side: "+p.Residual.String())
+	}
+	if len(parts) == 0 {
+		return "no filter"
+	}
+	return strings.Join(parts, "; ")
+}
+
+// CompileFilter validates filter and splits it into the conditions DCTrack
+// can evaluate and the rest. Quicksearch column filters are exact matches
+// combined with and, so only top-level equality conditions on text fields
+// are pushed down.
+func CompileFilter(filter Filter) (*FilterPlan, error) {
+	plan := &FilterPlan{}
+	if filter == nil {
+		return plan, nil
+	}
+
+	columns := make(map[string]bool)
+	if err := walkConditions(filter, func(c *Condition) error {
+		if err := c.validate(); err != nil {
+			return err
+		}
+		field, _ := lookupFilterField(c.Field)
+		columns[field.column] = true
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	for column := range columns {
+		plan.Columns = append(plan.Columns, column)
+	}
+	sort.Strings(plan.Columns)
+
+	terms := []Filter{filter}
+	if and, ok := filter.(*andFilter); ok {
+		terms = and.terms
+	}
+
+	var residual []Filter
+	for _, term := range terms {
+		if condition, ok := term.(*Condition); ok && pushable(condition) {
+			plan.Pushed = append(plan.Pushed, condition)
+			continue
+		}
+		residual = append(residual, term)
+	}
+	switch len(residual) {
+	case 0:
+	case 1:
+		plan.Residual = residual[0]
+	default:
+		plan.Residual = And(residual...)
+	}
+	return plan, nil
+}
+
+// pushable reports whether DCTrack can evaluate a condition: equality, or
+// in with a single value, on a text field
+func pushable(c *Condition) bool {
+	field, _ := lookupFilterField(c.Field)
+	if field.kind != fieldText {
+		return false
+	}
+	return c.Operator == OpEq || (c.Operator == OpIn && len(c.Values) == 1)
+}
+
+// walkConditions calls fn for every condition in filter
+func walkConditions(filter Filter, fn func(*Condition) error) error {
+	switch f := filter.(type) {
+	case *Condition:
+		return fn(f)
+	case *andFilter:
+		for _, term := range f.terms {
+			if err := walkConditions(term, fn); err != nil {
+				return err
+			}
+		}
+	case *orFilter:
+		for _, term := range f.terms {
+			if err := walkConditions(term, fn); err != nil {
+				return err
+			}
+		}
+	case *notFilter:
+		return walkConditions(f.term, fn)
+	case nil:
+		return fmt.Errorf("empty filter")
+	default:
+		return fmt.Errorf("unsupported filter %T", filter)
+	}
+	return nil
+}
+
+// GetItemsWhere retrieves the items matching params and filter. The parts
+// of filter DCTrack supports are sent as column filters and the rest is
+// evaluated on the returned items; CompileFilter reports the split.
+func (c *Client) GetItemsWhere(ctx context.Context, params ItemsParams, filter Filter) ([]DCTrackItem, error) {
+	plan, err := CompileFilter(filter)
+	if err != nil {
+		return nil, fmt.Errorf("error compiling filter: %w", err)
+	}
+
+	payload, err := c.itemsPayload(params)
+	if err != nil {
+		return nil, fmt.Errorf("error selecting columns: %w", err)
+	}
+	for _, column := range plan.Columns {
+		withColumn(payload, column)
+	}
+	for _, condition := range plan.Pushed {
+		field, _ := lookupFilterField(condition.Field)
+		withColumnFilter(payload, field.column, filterText(condition.Values[0]))
+	}
+
+	c.logger.Info("Compiled DCTrack filter", zap.String("plan", plan.String()))
+
+	items, err := c.collectItems(ctx, params, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []DCTrackItem
+	for _, item := range items {
+		if filter == nil || filter.Match(item) {
+			matched = append(matched, item)
+		}
+	}
+
+	c.logger.Info("Filtered DCTrack items",
+		zap.Int("fetched", len(items)),
+		zap.Int("matched", len(matched)))
+	return matched, nil
+}
+
+// fieldKind is how a filter field's values are compared
+type fieldKind int
+
+const (
+	fieldText fieldKind = iota
+	fieldNumber
+	fieldDate
+	fieldAny // Custom fields: numbers, then dates, then text
+)
+
+// filterField describes a field filters can refer to
+type filterField struct {
+	column string
+	kind   fieldKind
+	value  func(item DCTrackItem) interface{}
+}
+
+// filterFields are keyed by lower-case name; each is also reachable by its
+// column
+var filterFields = map[string]filterField{
+	"name":             {"tiName", fieldText, func(i DCTrackItem) interface{} { return i.Name }},
+	"class":            {"tiClass", fieldText, func(i DCTrackItem) interface{} { return i.ItemClass }},
+	"subclass":         {"tiSubclass", fieldText, func(i DCTrackItem) interface{} { return i.TiSubclass }},
+	"status":           {"cmbStatus", fieldText, func(i DCTrackItem) interface{} { return i.Status }},
+	"location":         {"cmbLocation", fieldText, func(i DCTrackItem) interface{} { return i.Location }},
+	"cabinet":          {"cmbCabinet", fieldText, func(i DCTrackItem) interface{} { return i.Cabinet }},
+	"make":             {"cmbMake", fieldText, func(i DCTrackItem) interface{} { return i.Make }},
+	"model":            {"cmbModel", fieldText, func(i DCTrackItem) interface{} { return i.Model }},
+	"serial":           {"tiSerialNumber", fieldText, func(i DCTrackItem) interface{} { return i.SerialNumber }},
+	"assettag":         {"tiAssetTag", fieldText, func(i DCTrackItem) interface{} { return i.TiAssetTag }},
+	"team":             {"cmbSystemAdminTeam", fieldText, func(i DCTrackItem) interface{} { return i.SystemAdminTeam }},
+	"customer":         {"cmbCustomer", fieldText, func(i DCTrackItem) interface{} { return i.CmbCustomer }},
+	"room":             {"tiRoomName", fieldText, func(i DCTrackItem) interface{} { return i.TiRoomName }},
+	"row":              {"cmbRowLabel", fieldText, func(i DCTrackItem) interface{} { return i.CmbRowLabel }},
+	"position":         {"cmbUPosition", fieldNumber, func(i DCTrackItem) interface{} { return i.Position }},
+	"ru":               {"tiRUs", fieldNumber, func(i DCTrackItem) interface{} { return float64(i.Height) }},
+	"power":            {"tiEffectivePower", fieldNumber, func(i DCTrackItem) interface{} { return i.TiEffectivePower }},
+	"originalpower":    {"tiItemOriginalPower", fieldNumber, func(i DCTrackItem) interface{} { return i.OriginalPower }},
+	"potentialpower":   {"tiPotentialPower", fieldNumber, func(i DCTrackItem) interface{} { return i.TiPotentialPower }},
+	"weight":           {"tiWeight", fieldNumber, func(i DCTrackItem) interface{} { return i.TiWeight }},
+	"purchaseprice":    {"tiPurchasePrice", fieldNumber, func(i DCTrackItem) interface{} { return i.TiPurchasePrice }},
+	"installationdate": {"installationDate", fieldDate, func(i DCTrackItem) interface{} { return i.InstallationDate }},
+	"contractenddate":  {"contractEndDate", fieldDate, func(i DCTrackItem) interface{} { return i.ContractEndDate }},
+	"purchasedate":     {"purchaseDate", fieldDate, func(i DCTrackItem) interface{} { return i.PurchaseDate }},
+	"decommdate":       {"tiPlannedDecommDate", fieldDate, func(i DCTrackItem) interface{} { return i.TiPlannedDecommDate }},
+	"lastupdated":      {"lastUpdatedOn", fieldDate, func(i DCTrackItem) interface{} { return i.LastUpdatedAt }},
+}
+
+// filterFieldsByColumn indexes filterFields by lower-case column
+var filterFieldsByColumn = func() map[string]filterField {
+	byColumn := make(map[string]filterField, len(filterFields))
+	for _, field := range filterFields {
+		byColumn[strings.ToLower(field.column)] = field
+	}
+	return byColumn
+}()
+
+// lookupFilterField resolves a field name, column or custom field column
+func lookupFilterField(name string) (filterField, bool) {
+	key := strings.ToLower(name)
+	if field, ok := filterFields[key]; ok {
+		return field, true
+	}
+	if field, ok := filterFieldsByColumn[key]; ok {
+		return field, true
+	}
+
+	if len(name) > len(customFieldPrefix) && strings.EqualFold(name[:len(customFieldPrefix)], customFieldPrefix) {
+		custom := name[len(customFieldPrefix):]
+		return filterField{
+			column: CustomFieldColumn(custom),
+			kind:   fieldAny,
+			value: func(i DCTrackItem) interface{} {
+				value, _ := i.CustomField(custom)
+				return value.Raw()
+			},
+		}, true
+	}
+	return filterField{}, false
+}
+
+// isNullValue reports an empty text, zero number or missing date
+func isNullValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(v) == ""
+	case float64:
+		return v == 0
+	case *time.Time:
+		return v == nil || v.IsZero()
+	case time.Time:
+		return v.IsZero()
+	}
+	return false
+}
+
+// compareValues orders an item's value against a filter value as kind,
+// returning -1, 0 or 1. Dates are equal when they fall on the same day, and
+// text compares case included, as DCTrack's column filters do.
+func compareValues(kind fieldKind, value, target interface{}) int {
+	if kind == fieldAny {
+		if _, ok := filterNumber(value); ok {
+			if _, ok := filterNumber(target); ok {
+				kind = fieldNumber
+			}
+		}
+	}
+	if kind == fieldAny {
+		if _, ok := filterDate(value); ok {
+			if _, ok := filterDate(target); ok {
+				kind = fieldDate
+			}
+		}
+	}
+
+	switch kind {
+	case fieldNumber:
+		a, _ := filterNumber(value)
+		b, _ := filterNumber(target)
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+		return 0
+	case fieldDate:
+		a, _ := filterDate(value)
+		b, _ := filterDate(target)
+		if a.Format(inputDateFormat) == b.Format(inputDateFormat) && (isDateOnly(target) || isDateOnly(value)) {
+			return 0
+		}
+		return a.Compare(b)
+	}
+	return strings.Compare(filterText(value), filterText(target))
+}
+
+// isDateOnly reports a value given as a date without a time of day
+func isDateOnly(value interface{}) bool {
+	if text, ok := value.(string); ok {
+		_, err := time.Parse(inputDateFormat, strings.TrimSpace(text))
+		return err == nil
+	}
+	return false
+}
+
+// filterText formats a filter or item value as text
+func filterText(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case time.Time:
+		if v.Hour() == 0 && v.Minute() == 0 && v.Second() == 0 && v.Nanosecond() == 0 {
+			return v.Format(inputDateFormat)
+		}
+		return v.Format(time.RFC3339)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return filterText(*v)
+	case CustomFieldValue:
+		return v.String()
+	}
+	return formatValue(value)
+}
+
+// filterNumber reads a number from a filter or item value
+func filterNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	}
+	f, err := strconv.ParseFloat(strings.TrimSpace(filterText(value)), 64)
+	return f, err == nil
+}
+
+// filterDate reads a time from a filter or item value
+func filterDate(value interface{}) (time.Time, bool) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, true
+	case *time.Time:
+		if v == nil {
+			return time.Time{}, false
+		}
+		return *v, true
+	}
+	text := strings.TrimSpace(filterText(value))
+	if text == "" {
+		return time.Time{}, false
+	}
+	t, err := parseTime(text)
+	return t, err == nil
+}
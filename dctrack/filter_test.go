@@ -0,0 +1,277 @@
+package dctrack
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func filterTestItems() []DCTrackItem {
+	date := func(s string) *time.Time {
+		t, _ := time.Parse(inputDateFormat, s)
+		return &t
+	}
+	return []DCTrackItem{
+		{ID: "1", Name: "web-01", Make: "Dell", Model: "PowerEdge R750", Status: "Installed", Cabinet: "CAB-A1",
+			Height: 2, TiEffectivePower: 650, ContractEndDate: date("2024-12-31"),
+			CustomFields: map[string]CustomFieldValue{"Rack Budget": NewCustomFieldValue("1200")}},
+		{ID: "2", Name: "web-02", Make: "HPE", Model: "DL380", Status: "Installed", Cabinet: "CAB-A1",
+			Height: 2, TiEffectivePower: 400, ContractEndDate: date("2026-06-30")},
+		{ID: "3", Name: "sw-01", Make: "Cisco", Model: "Nexus 9336C", Status: "Planned", Height: 1},
+		{ID: "4", Name: "db-01", Make: "dell", Model: "PowerEdge R650", Status: "Installed", Cabinet: "CAB-B2",
+			Height: 1, TiEffectivePower: 900, ContractEndDate: date("2025-01-01")},
+	}
+}
+
+func matchingIDs(filter Filter, items []DCTrackItem) []string {
+	var ids []string
+	for _, item := range items {
+		if filter.Match(item) {
+			ids = append(ids, item.ID)
+		}
+	}
+	return ids
+}
+
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected string // Canonical form
+		ids      []string
+	}{
+		{"make in (Dell,HPE) and power > 500", "make in (Dell, HPE) and power > 500", []string{"1"}},
+		{"make = dell", "make = dell", []string{"4"}},
+		{"status != Installed", "status != Installed", []string{"3"}},
+		{"power between 400 and 650", "power between 400 and 650", []string{"1", "2"}},
+		{"model contains poweredge or ru >= 2", "model contains poweredge or ru >= 2", []string{"1", "2", "4"}},
+		{"cabinet is null", "cabinet is null", []string{"3"}},
+		{"cabinet is not null and power <= 400", "not cabinet is null and power <= 400", []string{"2"}},
+		{"contractEndDate before 2025-01-01", "contractEndDate < 2025-01-01", []string{"1"}},
+		{"contractEndDate after 2024-12-31", "contractEndDate > 2024-12-31", []string{"2", "4"}},
+		{"contractEndDate = 2025-01-01", "contractEndDate = 2025-01-01", []string{"4"}},
+		{`model = "Nexus 9336C"`, `model = "Nexus 9336C"`, []string{"3"}},
+		{"NOT (make = Dell OR make = HPE)", "not (make = Dell or make = HPE)", []string{"3", "4"}},
+		{"make not in (Dell, Cisco)", "not make in (Dell, Cisco)", []string{"2", "4"}},
+		{"(status = Planned or power > 800) and cabinet != CAB-A1", "(status = Planned or power > 800) and cabinet != CAB-A1", []string{"3", "4"}},
+		{"cmbMake = HPE", "cmbMake = HPE", []string{"2"}},
+		{`"tiCustomField_Rack Budget" > 1000`, `"tiCustomField_Rack Budget" > 1000`, []string{"1"}},
+	}
+
+	items := filterTestItems()
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			filter, err := ParseFilter(tt.text)
+			if err != nil {
+				t.Fatalf("ParseFilter failed: %v", err)
+			}
+			if filter.String() != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, filter.String())
+			}
+			if ids := matchingIDs(filter, items); !reflect.DeepEqual(ids, tt.ids) {
+				t.Errorf("Expected items %v, got %v", tt.ids, ids)
+			}
+
+			// The canonical form parses back to the same filter
+			again, err := ParseFilter(filter.String())
+			if err != nil || again.String() != filter.String() {
+				t.Errorf("Expected %q to round-trip, got %v (%v)", filter.String(), again, err)
+			}
+		})
+	}
+}
+
+func TestParseFilterErrors(t *testing.T) {
+	tests := []string{
+		"",
+		"make",
+		"make =",
+		"colour = red",
+		"power > lots",
+		"contractEndDate before soon",
+		"make in (Dell, HPE",
+		"make in Dell",
+		"power between 1",
+		"(make = Dell",
+		"make = Dell)",
+		`make = "Dell`,
+		"make = Dell and",
+		"cabinet is empty",
+		"make not = Dell",
+	}
+
+	for _, text := range tests {
+		if filter, err := ParseFilter(text); err == nil {
+			t.Errorf("Expected error parsing %q, got %v", text, filter)
+		}
+	}
+}
+
+func TestCompileFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  Filter
+		plan    string
+		columns []string
+	}{
+		{
+			name:    "mixed",
+			filter:  And(In("make", "Dell"), Eq("status", "Installed"), Gt("power", 500)),
+			plan:    "pushed down: make in (Dell) and status = Installed; client-side: power > 500",
+			columns: []string{"cmbMake", "cmbStatus", "tiEffectivePower"},
+		},
+		{
+			name:    "single condition",
+			filter:  Eq("cabinet", "CAB-A1"),
+			plan:    "pushed down: cabinet = CAB-A1",
+			columns: []string{"cmbCabinet"},
+		},
+		{
+			name:    "or stays on the client",
+			filter:  Or(Eq("make", "Dell"), Eq("make", "HPE")),
+			plan:    "client-side: make = Dell or make = HPE",
+			columns: []string{"cmbMake"},
+		},
+		{
+			name:    "dates and negation stay on the client",
+			filter:  And(Before("contractEndDate", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)), Not(Eq("make", "Dell")), In("make", "Dell", "HPE")),
+			plan:    "client-side: contractEndDate < 2025-01-01 and not make = Dell and make in (Dell, HPE)",
+			columns: []string{"cmbMake", "contractEndDate"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := CompileFilter(tt.filter)
+			if err != nil {
+				t.Fatalf("CompileFilter failed: %v", err)
+			}
+			if plan.String() != tt.plan {
+				t.Errorf("Expected plan %q, got %q", tt.plan, plan.String())
+			}
+			if !reflect.DeepEqual(plan.Columns, tt.columns) {
+				t.Errorf("Expected columns %v, got %v", tt.columns, plan.Columns)
+			}
+		})
+	}
+
+	if _, err := CompileFilter(And(Eq("make", "Dell"), Between("power", 1, "x"))); err == nil {
+		t.Errorf("Expected error compiling a non-numeric power bound")
+	}
+}
+
+func TestConditionMissingValues(t *testing.T) {
+	items := filterTestItems()
+	conditions := []*Condition{
+		{Field: "make", Operator: OpEq},
+		{Field: "make", Operator: OpNe},
+		{Field: "power", Operator: OpGt},
+		{Field: "power", Operator: OpBetween, Values: []interface{}{100}},
+		{Field: "make", Operator: OpContains},
+		In("make"),
+	}
+
+	for _, condition := range conditions {
+		if ids := matchingIDs(condition, items); ids != nil {
+			t.Errorf("Expected %s to match nothing, got %v", condition, ids)
+		}
+		if _, err := CompileFilter(condition); err == nil {
+			t.Errorf("Expected error compiling %s", condition)
+		}
+	}
+}
+
+func TestGetItemsWhere(t *testing.T) {
+	var (
+		filters []columnFilter
+		columns []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/authentication/login":
+			w.Header().Set("Authorization", "Bearer mock-token-12345")
+		case "/api/v2/quicksearch/items":
+			var payload struct {
+				SelectedColumns []map[string]string `json:"selectedColumns"`
+				Columns         []columnFilter      `json:"columns"`
+			}
+			json.NewDecoder(r.Body).Decode(&payload)
+			filters = payload.Columns
+			columns = nil
+			for _, column := range payload.SelectedColumns {
+				columns = append(columns, column["name"])
+			}
+			if r.URL.Query().Get("pageNumber") != "1" {
+				w.Write([]byte(`{"searchResults":{"items":[]}}`))
+				return
+			}
+
+			// Server ignores the make filter
+			w.Write([]byte(`{"searchResults":{"items":[
+				{"id":"1","tiName":"web-01","cmbMake":"Dell","cmbStatus":"Installed","tiEffectivePower":650,"contractEndDate":"2024-12-31"},
+				{"id":"3","tiName":"db-02","cmbMake":"dell","cmbStatus":"Installed","tiEffectivePower":800,"contractEndDate":"2024-12-31"},
+				{"id":"2","tiName":"web-02","cmbMake":"HPE","cmbStatus":"Installed","tiEffectivePower":700,"contractEndDate":"2026-06-30"},
+				{"id":"4","tiName":"db-01","cmbMake":"Dell","cmbStatus":"Installed","tiEffectivePower":300,"contractEndDate":"2024-03-01"}
+			]}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(server.URL+"/api/v2", "testuser", "testpass")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	filter, err := ParseFilter("make = Dell and (power > 500 or contractEndDate before 2024-06-01)")
+	if err != nil {
+		t.Fatalf("ParseFilter failed: %v", err)
+	}
+	items, err := client.GetItemsWhere(context.Background(), InstalledOnly(), filter)
+	if err != nil {
+		t.Fatalf("GetItemsWhere failed: %v", err)
+	}
+
+	var ids []string
+	for _, item := range items {
+		ids = append(ids, item.ID)
+	}
+	// Text matches case included, as on servers that apply the filters
+	if !reflect.DeepEqual(ids, []string{"1", "4"}) {
+		t.Errorf("Expected items 1 and 4, got %v", ids)
+	}
+
+	expected := []columnFilter{
+		{"cmbStatus", map[string]string{"eq": "Installed"}},
+		{"cmbMake", map[string]string{"eq": "Dell"}},
+	}
+	if !reflect.DeepEqual(filters, expected) {
+		t.Errorf("Expected column filters %v, got %v", expected, filters)
+	}
+
+	// The default minimal set lacks the power and date columns the filter reads
+	for _, column := range []string{"tiEffectivePower", "contractEndDate"} {
+		found := false
+		for _, selected := range columns {
+			found = found || selected == column
+		}
+		if !found {
+			t.Errorf("Expected %s to be selected, got %v", column, columns)
+		}
+	}
+
+	// A condition matches the same whether it is pushed down or not
+	for _, filter := range []Filter{Eq("make", "dell"), Or(Eq("make", "dell"), Eq("make", "zzz"))} {
+		items, err := client.GetItemsWhere(context.Background(), InstalledOnly(), filter)
+		if err != nil {
+			t.Fatalf("GetItemsWhere failed: %v", err)
+		}
+		if len(items) != 1 || items[0].ID != "3" {
+			t.Errorf("Expected only item 3 for %s, got %v", filter, items)
+		}
+	}
+}
@@ -0,0 +1,339 @@
+package dctrack
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ParseFilter parses the text form of a filter, as used on the command
+// line:
+//
+//	make in (Dell, HPE) and power > 500
+//	status = Installed and not (cabinet is null or ru between 1 and 2)
+//	contractEndDate before 2025-01-01 or "tiCustomField_Audit By" contains ops
+//
+// Keywords are case-insensitive. Comparisons are =, !=, >, >=, <, <=,
+// between, in, contains, is [not] null, before and after; in, contains and
+// between can be negated with not. Values with spaces or punctuation are
+// quoted with ' or ".
+func ParseFilter(text string) (Filter, error) {
+	tokens, err := tokenizeFilter(text)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty filter")
+	}
+
+	p := &filterParser{tokens: tokens}
+	filter, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if !p.done() {
+		return nil, fmt.Errorf("unexpected %q at position %d", p.peek().text, p.peek().pos)
+	}
+	if err := walkConditions(filter, func(c *Condition) error { return c.validate() }); err != nil {
+		return nil, err
+	}
+	return filter, nil
+}
+
+// filterToken is a word, quoted string, operator or punctuation
+type filterToken struct {
+	text   string
+	quoted bool
+	pos    int
+}
+
+// tokenizeFilter splits text into tokens
+func tokenizeFilter(text string) ([]filterToken, error) {
+	var tokens []filterToken
+	runes := []rune(text)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(' || r == ')' || r == ',':
+			tokens = append(tokens, filterToken{text: string(r), pos: i})
+			i++
+		case r == '=' || r == '!' || r == '<' || r == '>':
+			start := i
+			i++
+			if i < len(runes) && (runes[i] == '=' || (r == '<' && runes[i] == '>')) {
+				i++
+			}
+			op := string(runes[start:i])
+			if op == "!" {
+				return nil, fmt.Errorf("unexpected ! at position %d", start)
+			}
+			if op == "<>" || op == "==" {
+				op = map[string]string{"<>": "!=", "==": "="}[op]
+			}
+			tokens = append(tokens, filterToken{text: op, pos: start})
+		case r == '"' || r == '\'':
+			start := i
+			var b strings.Builder
+			for i++; i < len(runes) && runes[i] != r; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+				b.WriteRune(runes[i])
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("unterminated quote at position %d", start)
+			}
+			i++
+			tokens = append(tokens, filterToken{text: b.String(), quoted: true, pos: start})
+		default:
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && !strings.ContainsRune("(),=!<>\"'", runes[i]) {
+				i++
+			}
+			tokens = append(tokens, filterToken{text: string(runes[start:i]), pos: start})
+		}
+	}
+	return tokens, nil
+}
+
+// filterParser is a recursive descent parser over tokens
+type filterParser struct {
+	tokens []filterToken
+	next   int
+}
+
+func (p *filterParser) done() bool {
+	return p.next >= len(p.tokens)
+}
+
+func (p *filterParser) peek() filterToken {
+	if p.done() {
+		return filterToken{pos: -1}
+	}
+	return p.tokens[p.next]
+}
+
+// keyword consumes the next token if it is the unquoted word
+func (p *filterParser) keyword(word string) bool {
+	token := p.peek()
+	if !p.done() && !token.quoted && strings.EqualFold(token.text, word) {
+		p.next++
+		return true
+	}
+	return false
+}
+
+// expect consumes the unquoted word or fails
+func (p *filterParser) expect(word string) error {
+	if p.keyword(word) {
+		return nil
+	}
+	if p.done() {
+		return fmt.Errorf("expected %q at end of filter", word)
+	}
+	return fmt.Errorf("expected %q at position %d, got %q", word, p.peek().pos, p.peek().text)
+}
+
+// value consumes a value or field name
+func (p *filterParser) value(what string) (string, error) {
+	if p.done() {
+		return "", fmt.Errorf("expected %s at end of filter", what)
+	}
+	token := p.tokens[p.next]
+	if !token.quoted && (token.text == "(" || token.text == ")" || token.text == "," || isFilterOperator(token.text)) {
+		return "", fmt.Errorf("expected %s at position %d, got %q", what, token.pos, token.text)
+	}
+	p.next++
+	return token.text, nil
+}
+
+func (p *filterParser) parseOr() (Filter, error) {
+	terms, err := p.parseSequence("or", p.parseAnd)
+	if err != nil || len(terms) == 1 {
+		return first(terms), err
+	}
+	return Or(terms...), nil
+}
+
+func (p *filterParser) parseAnd() (Filter, error) {
+	terms, err := p.parseSequence("and", p.parseUnary)
+	if err != nil || len(terms) == 1 {
+		return first(terms), err
+	}
+	return And(terms...), nil
+}
+
+// parseSequence parses one or more terms separated by the keyword
+func (p *filterParser) parseSequence(separator string, term func() (Filter, error)) ([]Filter, error) {
+	var terms []Filter
+	for {
+		filter, err := term()
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, filter)
+		if !p.keyword(separator) {
+			return terms, nil
+		}
+	}
+}
+
+func (p *filterParser) parseUnary() (Filter, error) {
+	if p.keyword("not") {
+		filter, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return Not(filter), nil
+	}
+	if token := p.peek(); !token.quoted && token.text == "(" {
+		p.next++
+		filter, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if token := p.peek(); p.done() || token.quoted || token.text != ")" {
+			return nil, fmt.Errorf("missing ) for ( at position %d", token.pos)
+		}
+		p.next++
+		return filter, nil
+	}
+	return p.parseCondition()
+}
+
+func (p *filterParser) parseCondition() (Filter, error) {
+	field, err := p.value("a field")
+	if err != nil {
+		return nil, err
+	}
+
+	negate := p.keyword("not")
+	var condition *Condition
+	switch {
+	case p.keyword("in"):
+		values, err := p.parseList()
+		if err != nil {
+			return nil, err
+		}
+		condition = In(field, values...)
+	case p.keyword("contains"):
+		text, err := p.value("a value")
+		if err != nil {
+			return nil, err
+		}
+		condition = Contains(field, text)
+	case p.keyword("between"):
+		low, err := p.value("a value")
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect("and"); err != nil {
+			return nil, err
+		}
+		high, err := p.value("a value")
+		if err != nil {
+			return nil, err
+		}
+		condition = Between(field, low, high)
+	case negate:
+		return nil, fmt.Errorf("expected in, contains or between after %s not", field)
+	case p.keyword("is"):
+		negate = p.keyword("not")
+		if err := p.expect("null"); err != nil {
+			return nil, err
+		}
+		condition = IsNull(field)
+	case p.keyword("before"), p.keyword("after"):
+		op := OpLt
+		if strings.EqualFold(p.tokens[p.next-1].text, "after") {
+			op = OpGt
+		}
+		value, err := p.value("a date")
+		if err != nil {
+			return nil, err
+		}
+		condition = &Condition{Field: field, Operator: op, Values: []interface{}{value}}
+	default:
+		token := p.peek()
+		if p.done() || token.quoted || !isFilterOperator(token.text) {
+			return nil, fmt.Errorf("expected a comparison after %s at position %d", field, token.pos)
+		}
+		p.next++
+		value, err := p.value("a value")
+		if err != nil {
+			return nil, err
+		}
+		condition = &Condition{Field: field, Operator: Operator(token.text), Values: []interface{}{value}}
+	}
+
+	if negate {
+		return Not(condition), nil
+	}
+	return condition, nil
+}
+
+// parseList parses a parenthesized, comma-separated list of values
+func (p *filterParser) parseList() ([]interface{}, error) {
+	if token := p.peek(); p.done() || token.quoted || token.text != "(" {
+		return nil, fmt.Errorf("expected ( after in at position %d", token.pos)
+	}
+	p.next++
+
+	var values []interface{}
+	for {
+		value, err := p.value("a value")
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+
+		token := p.peek()
+		if p.done() || token.quoted || (token.text != "," && token.text != ")") {
+			return nil, fmt.Errorf("expected , or ) at position %d", token.pos)
+		}
+		p.next++
+		if token.text == ")" {
+			return values, nil
+		}
+	}
+}
+
+// first returns the only term of a one-term sequence
+func first(terms []Filter) Filter {
+	if len(terms) == 0 {
+		return nil
+	}
+	return terms[0]
+}
+
+// isFilterOperator reports a comparison operator token
+func isFilterOperator(text string) bool {
+	switch Operator(text) {
+	case OpEq, OpNe, OpGt, OpGe, OpLt, OpLe:
+		return true
+	}
+	return false
+}
+
+// filterKeywords must be quoted when used as values
+var filterKeywords = map[string]bool{
+	"and": true, "or": true, "not": true, "in": true, "contains": true, "between": true,
+	"is": true, "null": true, "before": true, "after": true,
+}
+
+// quoteFilterWord quotes a field or value when ParseFilter would not read
+// it back as a single word
+func quoteFilterWord(text string) string {
+	if text != "" && !filterKeywords[strings.ToLower(text)] &&
+		!strings.ContainsFunc(text, func(r rune) bool { return unicode.IsSpace(r) || strings.ContainsRune("(),=!<>\"'\\", r) }) {
+		return text
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(text) + `"`
+}
+
+// quoteFilterValue formats and quotes a value
+func quoteFilterValue(value interface{}) string {
+	return quoteFilterWord(filterText(value))
+}
@@ -0,0 +1,330 @@
+package dctrack
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// inputDateFormat is the date layout DCTrack accepts for date fields
+const inputDateFormat = "2006-01-02"
+
+// ItemInput describes the fields to write when creating or updating an
+// item. Nil fields are left out of the request, so an update only touches
+// the fields that are set. Each field is sent under the DCTrack key the
+// mapper reads it from.
+type ItemInput struct {
+	// Core identification
+	Name      *string
+	ItemClass *string
+	Subclass  *string
+	Status    *string
+
+	// Placement
+	Location  *string
+	Cabinet   *string
+	UPosition *string
+	Mounting  *string
+
+	// Asset information
+	Make         *string
+	Model        *string
+	SerialNumber *string
+	AssetTag     *string
+	PartNumber   *string
+	IPAddresses  *string
+	PONumber     *string
+	Price        *float64
+
+	// Ownership
+	SystemAdminTeam *string
+	SystemAdmin     *string
+	Customer        *string
+	PrimaryContact  *string
+	ContactTeamName *string
+	Notes           *string
+
+	// Dates, sent as YYYY-MM-DD
+	PurchaseDate      *time.Time
+	InstallationDate  *time.Time
+	ContractEndDate   *time.Time
+	PlannedDecommDate *time.Time
+
+	// CustomFields holds custom fields to write, keyed by field name
+	// without the tiCustomField_ prefix. time.Time values are sent as dates.
+	CustomFields map[string]interface{}
+
+	// Extra holds any other DCTrack keys to write. Entries override the
+	// fields above.
+	Extra map[string]interface{}
+}
+
+// Ptr returns a pointer to v, for filling in ItemInput fields
+func Ptr[T any](v T) *T {
+	return &v
+}
+
+// ItemInputFromItem reverse-maps the non-empty fields of item, e.g. to
+// copy an existing item or to write back an edited one
+func ItemInputFromItem(item DCTrackItem) ItemInput {
+	str := func(v string) *string {
+		if v == "" {
+			return nil
+		}
+		return &v
+	}
+	num := func(v float64) *float64 {
+		if v == 0 {
+			return nil
+		}
+		return &v
+	}
+
+	input := ItemInput{
+		Name:              str(item.Name),
+		ItemClass:         str(item.ItemClass),
+		Subclass:          str(item.TiSubclass),
+		Status:            str(item.Status),
+		Location:          str(item.Location),
+		Cabinet:           str(item.Cabinet),
+		UPosition:         str(item.Position),
+		Mounting:          str(item.TiMounting),
+		Make:              str(item.Make),
+		Model:             str(item.Model),
+		SerialNumber:      str(item.SerialNumber),
+		AssetTag:          str(item.TiAssetTag),
+		PartNumber:        str(item.TiPartNumber),
+		IPAddresses:       str(item.IPAddresses),
+		PONumber:          str(item.TiPoNumber),
+		Price:             num(item.TiPurchasePrice),
+		SystemAdminTeam:   str(item.CmbSystemAdminTeam),
+		SystemAdmin:       str(item.CmbSystemAdmin),
+		Customer:          str(item.CmbCustomer),
+		PrimaryContact:    item.TiCustomFieldPrimaryContact,
+		ContactTeamName:   item.TiCustomFieldContactTeamName,
+		Notes:             str(item.TiNotes),
+		PurchaseDate:      item.PurchaseDate,
+		InstallationDate:  item.InstallationDate,
+		ContractEndDate:   item.ContractEndDate,
+		PlannedDecommDate: item.TiPlannedDecommDate,
+	}
+	if input.SerialNumber == nil {
+		input.SerialNumber = str(item.TiSerialNumber)
+	}
+	if input.PrimaryContact == nil {
+		input.PrimaryContact = str(item.PrimaryContact)
+	}
+	if input.SystemAdminTeam == nil {
+		input.SystemAdminTeam = str(item.SystemAdminTeam)
+	}
+	for name, value := range item.CustomFields {
+		if value.IsZero() {
+			continue
+		}
+		if input.CustomFields == nil {
+			input.CustomFields = make(map[string]interface{})
+		}
+		input.CustomFields[name] = value.Raw()
+	}
+	return input
+}
+
+// ItemInputFromFields builds an input from values keyed by DCTrack field
+// name, as read from a spreadsheet or JSON export. Other tiCustomField_*
+// keys go to CustomFields and remaining keys to Extra. Empty values are
+// skipped so blank cells do not clear fields.
+func ItemInputFromFields(values map[string]interface{}) (ItemInput, error) {
+	input := ItemInput{}
+
+	stringFields := map[string]**string{
+		"tiName":                          &input.Name,
+		"tiClass":                         &input.ItemClass,
+		"tiSubclass":                      &input.Subclass,
+		"cmbStatus":                       &input.Status,
+		"cmbLocation":                     &input.Location,
+		"cmbCabinet":                      &input.Cabinet,
+		"cmbUPosition":                    &input.UPosition,
+		"tiMounting":                      &input.Mounting,
+		"cmbMake":                         &input.Make,
+		"cmbModel":                        &input.Model,
+		"tiSerialNumber":                  &input.SerialNumber,
+		"tiAssetTag":                      &input.AssetTag,
+		"tiPartNumber":                    &input.PartNumber,
+		"ipAddresses":                     &input.IPAddresses,
+		"tiPONumber":                      &input.PONumber,
+		"cmbSystemAdminTeam":              &input.SystemAdminTeam,
+		"cmbSystemAdmin":                  &input.SystemAdmin,
+		"cmbCustomer":                     &input.Customer,
+		"tiCustomField_Primary Contact":   &input.PrimaryContact,
+		"tiCustomField_Contact Team Name": &input.ContactTeamName,
+		"tiNotes":                         &input.Notes,
+	}
+	dates := map[string]**time.Time{
+		"purchaseDate":        &input.PurchaseDate,
+		"installationDate":    &input.InstallationDate,
+		"contractEndDate":     &input.ContractEndDate,
+		"tiPlannedDecommDate": &input.PlannedDecommDate,
+	}
+
+	for key, value := range values {
+		if value == nil {
+			continue
+		}
+		text := strings.TrimSpace(formatValue(value))
+		if text == "" {
+			continue
+		}
+
+		if field, ok := stringFields[key]; ok {
+			*field = Ptr(text)
+			continue
+		}
+		if field, ok := dates[key]; ok {
+			date, err := parseInputDate(text)
+			if err != nil {
+				return input, fmt.Errorf("invalid %s %q: %w", key, text, err)
+			}
+			*field = &date
+			continue
+		}
+		if key == "tiPurchasePrice" {
+			price, err := strconv.ParseFloat(text, 64)
+			if err != nil {
+				return input, fmt.Errorf("invalid %s %q: %w", key, text, err)
+			}
+			input.Price = &price
+			continue
+		}
+
+		if name, ok := strings.CutPrefix(key, customFieldPrefix); ok {
+			if input.CustomFields == nil {
+				input.CustomFields = make(map[string]interface{})
+			}
+			input.CustomFields[name] = value
+			continue
+		}
+
+		if input.Extra == nil {
+			input.Extra = make(map[string]interface{})
+		}
+		input.Extra[key] = value
+	}
+	return input, nil
+}
+
+// formatValue renders a decoded JSON or CSV value as text, keeping whole
+// numbers such as IDs out of exponent form
+func formatValue(value interface{}) string {
+	if f, ok := value.(float64); ok {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// parseInputDate accepts YYYY-MM-DD or RFC 3339 timestamps
+func parseInputDate(text string) (time.Time, error) {
+	if date, err := time.Parse(inputDateFormat, text); err == nil {
+		return date, nil
+	}
+	return time.Parse(time.RFC3339, text)
+}
+
+// Fields returns the set fields keyed by DCTrack field name, as sent in
+// the request body
+func (in ItemInput) Fields() map[string]interface{} {
+	fields := make(map[string]interface{})
+
+	setString := func(key string, v *string) {
+		if v != nil {
+			fields[key] = *v
+		}
+	}
+	setDate := func(key string, v *time.Time) {
+		if v != nil {
+			fields[key] = v.Format(inputDateFormat)
+		}
+	}
+
+	setString("tiName", in.Name)
+	setString("tiClass", in.ItemClass)
+	setString("tiSubclass", in.Subclass)
+	setString("cmbStatus", in.Status)
+
+	setString("cmbLocation", in.Location)
+	setString("cmbCabinet", in.Cabinet)
+	setString("cmbUPosition", in.UPosition)
+	setString("tiMounting", in.Mounting)
+
+	setString("cmbMake", in.Make)
+	setString("cmbModel", in.Model)
+	setString("tiSerialNumber", in.SerialNumber)
+	setString("tiAssetTag", in.AssetTag)
+	setString("tiPartNumber", in.PartNumber)
+	setString("ipAddresses", in.IPAddresses)
+	setString("tiPONumber", in.PONumber)
+	if in.Price != nil {
+		fields["tiPurchasePrice"] = *in.Price
+	}
+
+	setString("cmbSystemAdminTeam", in.SystemAdminTeam)
+	setString("cmbSystemAdmin", in.SystemAdmin)
+	setString("cmbCustomer", in.Customer)
+	setString("tiCustomField_Primary Contact", in.PrimaryContact)
+	setString("tiCustomField_Contact Team Name", in.ContactTeamName)
+	setString("tiNotes", in.Notes)
+
+	setDate("purchaseDate", in.PurchaseDate)
+	setDate("installationDate", in.InstallationDate)
+	setDate("contractEndDate", in.ContractEndDate)
+	setDate("tiPlannedDecommDate", in.PlannedDecommDate)
+
+	for name, value := range in.CustomFields {
+		if date, ok := value.(time.Time); ok {
+			value = date.Format(inputDateFormat)
+		}
+		if custom, ok := value.(CustomFieldValue); ok {
+			value = custom.Raw()
+		}
+		fields[CustomFieldColumn(name)] = value
+	}
+	for key, value := range in.Extra {
+		fields[key] = value
+	}
+	return fields
+}
+
+// requiredForCreate lists the DCTrack fields a new item must have
+var requiredForCreate = []string{"tiClass", "tiName", "cmbLocation"}
+
+// validate checks the input locally, reporting every problem by DCTrack
+// field name. Creates must carry the required fields; updates must change
+// at least one field.
+func (in ItemInput) validate(create bool) error {
+	fields := in.Fields()
+	var problems []ErrorDetail
+
+	if create {
+		for _, key := range requiredForCreate {
+			if value, ok := fields[key]; !ok || value == "" {
+				problems = append(problems, ErrorDetail{Field: key, Message: "is required"})
+			}
+		}
+	} else if len(fields) == 0 {
+		problems = append(problems, ErrorDetail{Message: "no fields to update"})
+	}
+
+	if in.UPosition != nil && *in.UPosition != "" {
+		if u, err := strconv.Atoi(*in.UPosition); err != nil || u < 1 {
+			problems = append(problems, ErrorDetail{Field: "cmbUPosition", Message: "must be a positive rack unit"})
+		}
+	}
+	if in.Price != nil && *in.Price < 0 {
+		problems = append(problems, ErrorDetail{Field: "tiPurchasePrice", Message: "cannot be negative"})
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Errors: problems}
+	}
+	return nil
+}
@@ -0,0 +1,336 @@
+package dctrack
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// itemStore is an httptest stand-in for the DCTrack items endpoints
+type itemStore struct {
+	mu       sync.Mutex
+	items    map[string]map[string]interface{}
+	nextID   int
+	writes   int
+	searches int
+}
+
+func newItemStore(t *testing.T) (*itemStore, *httptest.Server) {
+	t.Helper()
+	store := &itemStore{items: make(map[string]map[string]interface{}), nextID: 100}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v2/authentication/login" {
+			w.Header().Set("Authorization", "Bearer mock-token-12345")
+			return
+		}
+		store.serve(t, w, r)
+	}))
+	return store, server
+}
+
+func (s *itemStore) serve(t *testing.T, w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.URL.Path == "/api/v2/quicksearch/items" {
+		s.search(w, r)
+		return
+	}
+
+	id := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/api/v2/items"), "/")
+	var fields map[string]interface{}
+	if r.Method == "POST" || r.Method == "PUT" {
+		s.writes++
+		if err := json.NewDecoder(r.Body).Decode(&fields); err != nil {
+			t.Errorf("Invalid request body: %v", err)
+		}
+		if fields["cmbCabinet"] == "NO-SUCH-CAB" {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			w.Write([]byte(`{"message":"Validation failed","errors":[{"field":"cmbCabinet","message":"cabinet does not exist"}]}`))
+			return
+		}
+	}
+
+	switch {
+	case r.Method == "POST" && id == "":
+		s.nextID++
+		fields["id"] = s.nextID
+		s.items[fmt.Sprint(s.nextID)] = fields
+		json.NewEncoder(w).Encode(fields)
+	case r.Method == "GET" || r.Method == "PUT" || r.Method == "DELETE":
+		item, ok := s.items[id]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case "GET":
+			json.NewEncoder(w).Encode(item)
+		case "PUT":
+			for key, value := range fields {
+				item[key] = value
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"item": item})
+		case "DELETE":
+			s.writes++
+			delete(s.items, id)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	default:
+		t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// search returns, like quicksearch, every item with a value containing
+// searchText, holding only the selected columns
+func (s *itemStore) search(w http.ResponseWriter, r *http.Request) {
+	s.searches++
+	var payload struct {
+		SelectedColumns []struct {
+			Name string `json:"name"`
+		} `json:"selectedColumns"`
+	}
+	json.NewDecoder(r.Body).Decode(&payload)
+
+	var matches []map[string]interface{}
+	text := r.URL.Query().Get("searchText")
+	for _, item := range s.items {
+		for _, value := range item {
+			if r.URL.Query().Get("pageNumber") == "1" && strings.Contains(fmt.Sprint(value), text) {
+				row := make(map[string]interface{})
+				for _, column := range payload.SelectedColumns {
+					if value, ok := item[column.Name]; ok {
+						row[column.Name] = value
+					}
+				}
+				matches = append(matches, row)
+				break
+			}
+		}
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{"searchResults": map[string]interface{}{"items": matches}})
+}
+
+func TestItemInputFields(t *testing.T) {
+	install := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	input := ItemInput{
+		Name:             Ptr("web-01"),
+		Cabinet:          Ptr("CAB-A1"),
+		UPosition:        Ptr("12"),
+		SerialNumber:     Ptr("SN-1"),
+		Price:            Ptr(2500.0),
+		InstallationDate: &install,
+		Extra:            map[string]interface{}{"tiCustomField_Asset Status": "Active"},
+	}
+
+	fields := input.Fields()
+	expected := map[string]interface{}{
+		"tiName":                     "web-01",
+		"cmbCabinet":                 "CAB-A1",
+		"cmbUPosition":               "12",
+		"tiSerialNumber":             "SN-1",
+		"tiPurchasePrice":            2500.0,
+		"installationDate":           "2024-03-15",
+		"tiCustomField_Asset Status": "Active",
+	}
+	if len(fields) != len(expected) {
+		t.Errorf("Expected %d fields, got %d: %v", len(expected), len(fields), fields)
+	}
+	for key, want := range expected {
+		if fields[key] != want {
+			t.Errorf("Field %s: expected %v, got %v", key, want, fields[key])
+		}
+	}
+}
+
+func TestItemInputFromItemRoundTrip(t *testing.T) {
+	client := &Client{config: Config{}}
+	record := map[string]interface{}{
+		"id":                            "1",
+		"tiName":                        "db-01",
+		"tiClass":                       "Device",
+		"cmbLocation":                   "RDU2",
+		"cmbCabinet":                    "CAB-B2",
+		"cmbUPosition":                  "20",
+		"cmbMake":                       "Dell",
+		"tiSerialNumber":                "SN-9",
+		"tiAssetTag":                    "AT-9",
+		"tiPurchasePrice":               1200.5,
+		"tiCustomField_Primary Contact": "ops@example.com",
+		"contractEndDate":               "2027-01-31",
+	}
+
+	item, err := client.mapDCTrackRecord(record)
+	if err != nil {
+		t.Fatalf("Mapping failed: %v", err)
+	}
+	fields := ItemInputFromItem(item).Fields()
+
+	for key, value := range record {
+		if key == "id" {
+			if _, ok := fields[key]; ok {
+				t.Errorf("Expected id to be left out of the input")
+			}
+			continue
+		}
+		if fields[key] != value {
+			t.Errorf("Field %s: expected %v, got %v", key, value, fields[key])
+		}
+	}
+}
+
+func TestItemInputValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    ItemInput
+		create   bool
+		expected []string // Fields reported
+	}{
+		{"complete create", ItemInput{ItemClass: Ptr("Device"), Name: Ptr("a"), Location: Ptr("RDU2")}, true, nil},
+		{"missing required", ItemInput{Name: Ptr("a")}, true, []string{"tiClass", "cmbLocation"}},
+		{"bad position", ItemInput{UPosition: Ptr("top")}, false, []string{"cmbUPosition"}},
+		{"negative price", ItemInput{Price: Ptr(-1.0)}, false, []string{"tiPurchasePrice"}},
+		{"empty update", ItemInput{}, false, []string{""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.input.validate(tt.create)
+			if tt.expected == nil {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+
+			if !errors.Is(err, ErrValidation) {
+				t.Fatalf("Expected ErrValidation, got %v", err)
+			}
+			details := FieldErrors(err)
+			if len(details) != len(tt.expected) {
+				t.Fatalf("Expected %d field errors, got %+v", len(tt.expected), details)
+			}
+			for i, field := range tt.expected {
+				if details[i].Field != field {
+					t.Errorf("Expected field %q, got %q", field, details[i].Field)
+				}
+			}
+		})
+	}
+}
+
+func TestCreateUpdateDeleteItem(t *testing.T) {
+	store, server := newItemStore(t)
+	defer server.Close()
+
+	client, err := New(server.URL+"/api/v2", "testuser", "testpass")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx := context.Background()
+
+	created, err := client.CreateItem(ctx, ItemInput{
+		ItemClass:    Ptr("Device"),
+		Name:         Ptr("web-01"),
+		Location:     Ptr("RDU2"),
+		SerialNumber: Ptr("SN-1"),
+	})
+	if err != nil {
+		t.Fatalf("CreateItem failed: %v", err)
+	}
+	if created.ID != "101" || created.Name != "web-01" || created.SerialNumber != "SN-1" {
+		t.Errorf("Unexpected created item: %+v", created)
+	}
+
+	updated, err := client.UpdateItem(ctx, created.ID, ItemInput{Cabinet: Ptr("CAB-A1"), UPosition: Ptr("12")})
+	if err != nil {
+		t.Fatalf("UpdateItem failed: %v", err)
+	}
+	if updated.Cabinet != "CAB-A1" || updated.Position != "12" || updated.Name != "web-01" {
+		t.Errorf("Expected placement updated and other fields kept, got %+v", updated)
+	}
+	if stored := store.items["101"]; len(stored) != 7 {
+		t.Errorf("Expected partial update to add two fields, stored %v", stored)
+	}
+
+	if err := client.DeleteItem(ctx, created.ID); err != nil {
+		t.Fatalf("DeleteItem failed: %v", err)
+	}
+	if err := client.DeleteItem(ctx, created.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound deleting twice, got %v", err)
+	}
+}
+
+func TestWriteItemServerValidation(t *testing.T) {
+	_, server := newItemStore(t)
+	defer server.Close()
+
+	client, err := New(server.URL+"/api/v2", "testuser", "testpass")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	_, err = client.CreateItem(context.Background(), ItemInput{
+		ItemClass: Ptr("Device"),
+		Name:      Ptr("web-01"),
+		Location:  Ptr("RDU2"),
+		Cabinet:   Ptr("NO-SUCH-CAB"),
+	})
+	if !errors.Is(err, ErrValidation) {
+		t.Fatalf("Expected ErrValidation, got %v", err)
+	}
+	details := FieldErrors(err)
+	if len(details) != 1 || details[0].Field != "cmbCabinet" || details[0].Message != "cabinet does not exist" {
+		t.Errorf("Expected cmbCabinet field error, got %+v", details)
+	}
+}
+
+func TestWriteItemDryRun(t *testing.T) {
+	store, server := newItemStore(t)
+	defer server.Close()
+	store.items["7"] = map[string]interface{}{"id": "7", "tiName": "db-01", "cmbCabinet": "CAB-A1"}
+
+	client, err := New(server.URL+"/api/v2", "testuser", "testpass", WithDryRun())
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx := context.Background()
+
+	preview, err := client.CreateItem(ctx, ItemInput{ItemClass: Ptr("Device"), Name: Ptr("web-01"), Location: Ptr("RDU2")})
+	if err != nil {
+		t.Fatalf("Dry-run CreateItem failed: %v", err)
+	}
+	if preview.ID != "" || preview.Name != "web-01" {
+		t.Errorf("Expected preview without ID, got %+v", preview)
+	}
+
+	preview, err = client.UpdateItem(ctx, "7", ItemInput{Cabinet: Ptr("CAB-B2")})
+	if err != nil {
+		t.Fatalf("Dry-run UpdateItem failed: %v", err)
+	}
+	if preview.Cabinet != "CAB-B2" || preview.Name != "db-01" {
+		t.Errorf("Expected current item with change applied, got %+v", preview)
+	}
+
+	if err := client.DeleteItem(ctx, "7"); err != nil {
+		t.Errorf("Dry-run DeleteItem failed: %v", err)
+	}
+	if err := client.DeleteItem(ctx, "8"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for missing item in dry run, got %v", err)
+	}
+
+	if store.writes != 0 {
+		t.Errorf("Expected no writes in dry run, got %d", store.writes)
+	}
+	if store.items["7"]["cmbCabinet"] != "CAB-A1" {
+		t.Errorf("Expected stored item unchanged, got %v", store.items["7"])
+	}
+}
@@ -0,0 +1,285 @@
+package dctrack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// ItemResult is the outcome of looking up one ID with GetItemsByIDs
+type ItemResult struct {
+	ID   string
+	Item *DCTrackItem // Nil when Err is set
+	Err  error
+}
+
+// GetItemByID retrieves a single item from the items endpoint. The item
+// payload carries every field of the item, including those quicksearch
+// leaves out. A missing item satisfies errors.Is(err, ErrNotFound).
+func (c *Client) GetItemByID(ctx context.Context, id string) (*DCTrackItem, error) {
+	if id == "" {
+		return nil, errors.New("item ID cannot be empty")
+	}
+
+	item, err := c.fetchItem(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error getting item %s: %w", id, err)
+	}
+	return item, nil
+}
+
+// GetItemsByIDs looks up each ID with up to Concurrency requests in flight.
+// Results are returned in the order of ids, with per-ID errors, so one
+// missing item does not fail the batch. Repeated IDs are fetched once.
+func (c *Client) GetItemsByIDs(ctx context.Context, ids []string) []ItemResult {
+	results := make([]ItemResult, len(ids))
+	if len(ids) == 0 {
+		return results
+	}
+
+	// Map each distinct ID to the positions it fills
+	positions := make(map[string][]int)
+	var unique []string
+	for i, id := range ids {
+		if _, seen := positions[id]; !seen {
+			unique = append(unique, id)
+		}
+		positions[id] = append(positions[id], i)
+	}
+
+	workers := max(c.config.Concurrency, 1)
+	if workers > len(unique) {
+		workers = len(unique)
+	}
+
+	c.logger.Debug("Looking up DCTrack items by ID",
+		zap.Int("ids", len(unique)),
+		zap.Int("workers", workers))
+
+	var wg sync.WaitGroup
+	lookups := make(chan string)
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := range lookups {
+				item, err := c.GetItemByID(ctx, id)
+				for _, i := range positions[id] {
+					results[i] = ItemResult{ID: id, Item: item, Err: err}
+				}
+			}
+		}()
+	}
+
+	for _, id := range unique {
+		lookups <- id
+	}
+	close(lookups)
+	wg.Wait()
+
+	return results
+}
+
+// CreateItem creates an item from input, which must name at least the item
+// class, name and location. The created item is returned as DCTrack stored
+// it. Field problems found locally or by DCTrack can be listed with
+// FieldErrors. In dry-run mode nothing is sent and the item that would be
+// created is returned without an ID.
+func (c *Client) CreateItem(ctx context.Context, input ItemInput) (*DCTrackItem, error) {
+	if err := input.validate(true); err != nil {
+		return nil, fmt.Errorf("error creating item: %w", err)
+	}
+	fields := input.Fields()
+
+	if c.config.DryRun {
+		c.logger.Info("Dry run: not creating DCTrack item", zap.Any("fields", fields))
+		return c.previewItem(nil, fields), nil
+	}
+
+	record, err := c.writeItem(ctx, "create", "POST", c.itemURL("", true), fields)
+	if err != nil {
+		return nil, fmt.Errorf("error creating item: %w", err)
+	}
+
+	item, err := c.mapDCTrackRecord(mergeRecord(fields, record))
+	if err != nil {
+		return nil, fmt.Errorf("error creating item: %w", err)
+	}
+	c.logger.Info("Created DCTrack item", zap.String("id", item.ID), zap.String("name", item.Name))
+	return &item, nil
+}
+
+// UpdateItem changes only the fields set in input and returns the updated
+// item. In dry-run mode the current item is fetched and returned with the
+// changes applied, without writing anything.
+func (c *Client) UpdateItem(ctx context.Context, id string, input ItemInput) (*DCTrackItem, error) {
+	if id == "" {
+		return nil, errors.New("item ID cannot be empty")
+	}
+	if err := input.validate(false); err != nil {
+		return nil, fmt.Errorf("error updating item %s: %w", id, err)
+	}
+	fields := input.Fields()
+
+	if c.config.DryRun {
+		current, err := c.fetchItemRecord(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("error updating item %s: %w", id, err)
+		}
+		c.logger.Info("Dry run: not updating DCTrack item", zap.String("id", id), zap.Any("fields", fields))
+		return c.previewItem(current, fields), nil
+	}
+
+	record, err := c.writeItem(ctx, "update", "PUT", c.itemURL(id, true), fields)
+	if err != nil {
+		return nil, fmt.Errorf("error updating item %s: %w", id, err)
+	}
+
+	merged := mergeRecord(fields, record)
+	if _, ok := merged["id"]; !ok {
+		merged["id"] = id
+	}
+	item, err := c.mapDCTrackRecord(merged)
+	if err != nil {
+		return nil, fmt.Errorf("error updating item %s: %w", id, err)
+	}
+	c.logger.Info("Updated DCTrack item", zap.String("id", id), zap.Int("fields", len(fields)))
+	return &item, nil
+}
+
+// DeleteItem deletes an item. A missing item satisfies
+// errors.Is(err, ErrNotFound). In dry-run mode the item is only looked up.
+func (c *Client) DeleteItem(ctx context.Context, id string) error {
+	if id == "" {
+		return errors.New("item ID cannot be empty")
+	}
+
+	if c.config.DryRun {
+		if _, err := c.fetchItemRecord(ctx, id); err != nil {
+			return fmt.Errorf("error deleting item %s: %w", id, err)
+		}
+		c.logger.Info("Dry run: not deleting DCTrack item", zap.String("id", id))
+		return nil
+	}
+
+	if _, err := c.sendItemRequest(ctx, "delete", "DELETE", c.itemURL(id, false), nil); err != nil {
+		return fmt.Errorf("error deleting item %s: %w", id, err)
+	}
+	c.logger.Info("Deleted DCTrack item", zap.String("id", id))
+	return nil
+}
+
+// writeItem sends fields as the JSON body of a create or update
+func (c *Client) writeItem(ctx context.Context, operation, method, url string, fields map[string]interface{}) (map[string]interface{}, error) {
+	payloadBytes, err := json.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling payload: %w", err)
+	}
+	return c.sendItemRequest(ctx, operation, method, url, payloadBytes)
+}
+
+// previewItem maps current with fields applied, for dry runs. Strict mapping
+// is not enforced on previews.
+func (c *Client) previewItem(current, fields map[string]interface{}) *DCTrackItem {
+	item, _ := c.mapDCTrackRecord(mergeRecord(current, fields))
+	return &item
+}
+
+// mergeRecord returns base overlaid with the keys of overlay
+func mergeRecord(base, overlay map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(base)+len(overlay))
+	for key, value := range base {
+		merged[key] = value
+	}
+	for key, value := range overlay {
+		merged[key] = value
+	}
+	return merged
+}
+
+// fetchItem requests GET /items/{id} and maps the returned item
+func (c *Client) fetchItem(ctx context.Context, id string) (*DCTrackItem, error) {
+	record, err := c.fetchItemRecord(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := c.mapDCTrackRecord(record)
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// fetchItemRecord requests GET /items/{id} and returns the raw record
+func (c *Client) fetchItemRecord(ctx context.Context, id string) (map[string]interface{}, error) {
+	return c.sendItemRequest(ctx, "item", "GET", c.itemURL(id, false), nil)
+}
+
+// sendItemRequest sends a request to an items endpoint and decodes the
+// item record in the response, if any
+func (c *Client) sendItemRequest(ctx context.Context, operation, method, url string, body []byte) (map[string]interface{}, error) {
+	// Repeating a create could add the item twice
+	resp, err := c.doAuthorized(ctx, operation, method, url, method != "POST", body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response body: %w", err)
+	}
+	// Writes may answer with no content; deletes carry nothing worth mapping
+	if method == "DELETE" || (method != "GET" && len(bytes.TrimSpace(bodyBytes)) == 0) {
+		return nil, nil
+	}
+
+	record, err := decodeItemRecord(bodyBytes)
+	if err != nil {
+		c.logger.Error("Failed to decode DCTrack item",
+			zap.String("operation", operation),
+			zap.Error(err),
+			zap.String("response_preview", string(bodyBytes)[:minInt(500, len(bodyBytes))]))
+		return nil, err
+	}
+	return record, nil
+}
+
+// itemURL returns the items endpoint, or a single item's when id is set.
+// returnDetails asks DCTrack to answer a write with the stored item.
+func (c *Client) itemURL(id string, returnDetails bool) string {
+	itemURL := c.config.URL + "/items"
+	if id != "" {
+		itemURL += "/" + url.PathEscape(id)
+	}
+	if returnDetails {
+		itemURL += "?returnDetails=true"
+	}
+	return itemURL
+}
+
+// decodeItemRecord accepts the item either as the document itself or
+// wrapped in an "item" member, as some DCTrack versions return it
+func decodeItemRecord(body []byte) (map[string]interface{}, error) {
+	var record map[string]interface{}
+	if err := json.Unmarshal(body, &record); err != nil {
+		return nil, fmt.Errorf("error decoding response: %w", err)
+	}
+
+	if wrapped, ok := record["item"].(map[string]interface{}); ok {
+		record = wrapped
+	}
+	if len(record) == 0 {
+		return nil, fmt.Errorf("empty item in response: %w", ErrMappingFailed)
+	}
+	return record, nil
+}
@@ -0,0 +1,167 @@
+package dctrack
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newItemServer serves GET /items/{id} from items, keyed by ID, and 404 otherwise
+func newItemServer(t *testing.T, items map[string]string, inFlight, peak *atomic.Int32) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v2/authentication/login" {
+			w.Header().Set("Authorization", "Bearer mock-token-12345")
+			return
+		}
+		if r.Method != "GET" || !strings.HasPrefix(r.URL.Path, "/api/v2/items/") {
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if inFlight != nil {
+			n := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		body, ok := items[strings.TrimPrefix(r.URL.Path, "/api/v2/items/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"Not Found"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+}
+
+func TestGetItemByID(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         string
+		expectedName string
+	}{
+		{
+			name:         "flat item",
+			body:         `{"id":12345,"tiName":"web-01","tiClass":"Device","cmbLocation":"RDU2","tiSerialNumber":"SN-1"}`,
+			expectedName: "web-01",
+		},
+		{
+			name:         "wrapped item",
+			body:         `{"item":{"id":"12345","tiName":"web-02","tiClass":"Device","cmbLocation":"RDU2"}}`,
+			expectedName: "web-02",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newItemServer(t, map[string]string{"12345": tt.body}, nil, nil)
+			defer server.Close()
+
+			client, err := New(server.URL+"/api/v2", "testuser", "testpass")
+			if err != nil {
+				t.Fatalf("Failed to create client: %v", err)
+			}
+
+			item, err := client.GetItemByID(context.Background(), "12345")
+			if err != nil {
+				t.Fatalf("GetItemByID failed: %v", err)
+			}
+			if item.ID != "12345" {
+				t.Errorf("Expected ID 12345, got %s", item.ID)
+			}
+			if item.Name != tt.expectedName {
+				t.Errorf("Expected name %s, got %s", tt.expectedName, item.Name)
+			}
+		})
+	}
+}
+
+func TestGetItemByIDIgnoresSearchRanking(t *testing.T) {
+	// Quicksearch would rank server-12345 first; the items endpoint is exact
+	server := newItemServer(t, map[string]string{
+		"other": `{"id":"other","tiName":"server-12345"}`,
+		"12345": `{"id":"12345","tiName":"db-01"}`,
+	}, nil, nil)
+	defer server.Close()
+
+	client, err := New(server.URL+"/api/v2", "testuser", "testpass")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	item, err := client.GetItemByID(context.Background(), "12345")
+	if err != nil {
+		t.Fatalf("GetItemByID failed: %v", err)
+	}
+	if item.Name != "db-01" {
+		t.Errorf("Expected db-01, got %s", item.Name)
+	}
+
+	if _, err := client.GetItemByID(context.Background(), "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+	if _, err := client.GetItemByID(context.Background(), ""); err == nil {
+		t.Errorf("Expected error for empty ID")
+	}
+}
+
+func TestGetItemsByIDs(t *testing.T) {
+	items := map[string]string{
+		"1": `{"id":"1","tiName":"item-1"}`,
+		"2": `{"id":"2","tiName":"item-2"}`,
+		"3": `{"id":"3","tiName":"item-3"}`,
+		"4": `{"id":"4","tiName":"item-4"}`,
+		"5": `{"id":"5","tiName":"item-5"}`,
+	}
+	var inFlight, peak atomic.Int32
+	server := newItemServer(t, items, &inFlight, &peak)
+	defer server.Close()
+
+	client, err := New(server.URL+"/api/v2", "testuser", "testpass", WithConcurrency(2))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ids := []string{"5", "missing", "1", "3", "2", "4", "1"}
+	results := client.GetItemsByIDs(context.Background(), ids)
+
+	if len(results) != len(ids) {
+		t.Fatalf("Expected %d results, got %d", len(ids), len(results))
+	}
+	for i, result := range results {
+		if result.ID != ids[i] {
+			t.Errorf("Result %d: expected ID %s, got %s", i, ids[i], result.ID)
+		}
+		if ids[i] == "missing" {
+			if !errors.Is(result.Err, ErrNotFound) || result.Item != nil {
+				t.Errorf("Expected ErrNotFound for missing ID, got %+v", result)
+			}
+			continue
+		}
+		if result.Err != nil {
+			t.Errorf("Result %d: unexpected error %v", i, result.Err)
+			continue
+		}
+		if result.Item.Name != "item-"+ids[i] {
+			t.Errorf("Result %d: expected item-%s, got %s", i, ids[i], result.Item.Name)
+		}
+	}
+
+	if peak.Load() > 2 {
+		t.Errorf("Expected at most 2 lookups in flight, got %d", peak.Load())
+	}
+}
@@ -0,0 +1,183 @@
+package dctrack
+
+import (
+	"context"
+	"fmt"
+	"iter"
+
+	"go.uber.org/zap"
+)
+
+// PageError reports the page that failed while streaming items. Set
+// ItemsParams.PageNumber to Page to resume from it.
+type PageError struct {
+	Page int
+	Err  error
+}
+
+func (e *PageError) Error() string {
+	return fmt.Sprintf("error fetching page %d: %v", e.Page, e.Err)
+}
+
+func (e *PageError) Unwrap() error {
+	return e.Err
+}
+
+// ItemCursor walks the items matching a query one page at a time, holding
+// only the current page in memory.
+//
+//	cursor := client.NewItemCursor(dctrack.ByLocation("RDU2"))
+//	for cursor.Next(ctx) {
+//		item := cursor.Item()
+//		...
+//	}
+//	if err := cursor.Err(); err != nil {
+//		...
+//	}
+type ItemCursor struct {
+	client   *Client
+	params   ItemsParams
+	pageSize int
+
+	nextPage  int           // Page fetched by the next call to fetch
+	page      int           // Page holding the current item
+	buffer    []DCTrackItem // Remaining items of the current page
+	item      DCTrackItem
+	totalRows int
+	yielded   int
+	done      bool
+	err       error
+}
+
+// NewItemCursor creates a cursor over the items matching params. Unlike
+// GetItemsWithParams, a PageNumber starts the walk at that page and carries
+// on to the last one, which is how an interrupted walk is resumed.
+func (c *Client) NewItemCursor(params ItemsParams) *ItemCursor {
+	pageSize := c.config.PageSize
+	if params.PageSize > 0 {
+		pageSize = params.PageSize
+	}
+
+	startPage := 1
+	if params.PageNumber > 0 {
+		startPage = params.PageNumber
+	}
+
+	return &ItemCursor{
+		client:   c,
+		params:   params,
+		pageSize: pageSize,
+		nextPage: startPage,
+	}
+}
+
+// Items streams the items matching params page by page. A failed page is
+// yielded once as a *PageError and ends the sequence.
+func (c *Client) Items(ctx context.Context, params ItemsParams) iter.Seq2[DCTrackItem, error] {
+	return c.NewItemCursor(params).All(ctx)
+}
+
+// Next advances to the next item, fetching the next page when the current
+// one is exhausted. It returns false at the end of the results, on error
+// or once ctx is cancelled; check Err afterwards.
+func (cur *ItemCursor) Next(ctx context.Context) bool {
+	for len(cur.buffer) == 0 {
+		if cur.done || cur.err != nil {
+			return false
+		}
+		if err := ctx.Err(); err != nil {
+			cur.err = &PageError{Page: cur.nextPage, Err: err}
+			return false
+		}
+		cur.fetch(ctx)
+	}
+
+	cur.item = cur.buffer[0]
+	cur.buffer = cur.buffer[1:]
+	cur.yielded++
+	return true
+}
+
+// fetch loads the next page into the buffer
+func (cur *ItemCursor) fetch(ctx context.Context) {
+	c := cur.client
+	payload, err := c.itemsPayload(cur.params)
+	if err != nil {
+		cur.err = &PageError{Page: cur.nextPage, Err: fmt.Errorf("error selecting columns: %w", err)}
+		return
+	}
+
+	result, err := c.fetchPage(ctx, c.itemsURL(cur.params, cur.nextPage, cur.pageSize), payload)
+	if err != nil {
+		cur.err = &PageError{Page: cur.nextPage, Err: err}
+		return
+	}
+
+	c.logger.Debug("Streamed DCTrack page",
+		zap.Int("page", cur.nextPage),
+		zap.Int("items_count", len(result.items)),
+		zap.Int("total_rows", result.totalRows))
+
+	cur.page = cur.nextPage
+	cur.nextPage++
+	cur.buffer = result.items
+	if result.totalRows > 0 {
+		cur.totalRows = result.totalRows
+	}
+
+	// A short or empty page is the last one
+	if result.records < cur.pageSize {
+		cur.done = true
+	}
+	if cur.totalRows > 0 && cur.page*cur.pageSize >= cur.totalRows {
+		cur.done = true
+	}
+}
+
+// Item returns the current item
+func (cur *ItemCursor) Item() DCTrackItem {
+	return cur.item
+}
+
+// Err returns the error that stopped the cursor, as a *PageError, or nil
+// if it reached the end of the results
+func (cur *ItemCursor) Err() error {
+	return cur.err
+}
+
+// Page returns the page number holding the current item
+func (cur *ItemCursor) Page() int {
+	return cur.page
+}
+
+// NextPage returns the page the cursor will fetch next. After a failure it
+// is the page to resume from.
+func (cur *ItemCursor) NextPage() int {
+	return cur.nextPage
+}
+
+// TotalRows returns the total number of matching rows reported by DCTrack,
+// or 0 until the first page has been fetched or if the server omits it
+func (cur *ItemCursor) TotalRows() int {
+	return cur.totalRows
+}
+
+// Yielded returns the number of items returned by Next so far
+func (cur *ItemCursor) Yielded() int {
+	return cur.yielded
+}
+
+// All adapts the cursor to a range-over-func sequence. TotalRows and
+// Yielded may be read inside the loop to report progress.
+func (cur *ItemCursor) All(ctx context.Context) iter.Seq2[DCTrackItem, error] {
+	return func(yield func(DCTrackItem, error) bool) {
+		for cur.Next(ctx) {
+			if !yield(cur.Item(), nil) {
+				return
+			}
+		}
+		if err := cur.Err(); err != nil {
+			yield(DCTrackItem{}, err)
+		}
+	}
+}
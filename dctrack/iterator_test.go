@@ -0,0 +1,208 @@
+package dctrack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// newPagingServer serves total items across pages of the requested size.
+// Requests for failPage return 500 while failing is set.
+func newPagingServer(t *testing.T, total int, failPage int, failing *atomic.Bool, requests *atomic.Int32) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/authentication/login":
+			w.Header().Set("Authorization", "Bearer mock-token-12345")
+			w.WriteHeader(http.StatusOK)
+		case "/api/v2/quicksearch/items":
+			requests.Add(1)
+			page, _ := strconv.Atoi(r.URL.Query().Get("pageNumber"))
+			size, _ := strconv.Atoi(r.URL.Query().Get("pageSize"))
+			if page == failPage && failing.Load() {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			var records []string
+			for i := (page - 1) * size; i < page*size && i < total; i++ {
+				records = append(records, fmt.Sprintf(`{"id":"%d","tiName":"item-%d","cmbLocation":"RDU2"}`, i, i))
+			}
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"totalRows":%d,"pageNumber":%d,"pageSize":%d,"searchResults":{"items":[%s]}}`,
+				total, page, size, strings.Join(records, ","))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func newIteratorTestClient(t *testing.T, url string) *Client {
+	t.Helper()
+	client, err := New(url+"/api/v2", "testuser", "testpass", WithPageSize(10), WithMaxRetries(1))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	return client
+}
+
+func TestItemsIterator(t *testing.T) {
+	var failing atomic.Bool
+	var requests atomic.Int32
+	server := newPagingServer(t, 25, 0, &failing, &requests)
+	defer server.Close()
+
+	client := newIteratorTestClient(t, server.URL)
+	cursor := client.NewItemCursor(ItemsParams{})
+
+	count := 0
+	for item, err := range cursor.All(context.Background()) {
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if item.ID != strconv.Itoa(count) {
+			t.Errorf("Expected item %d, got %s", count, item.ID)
+		}
+		count++
+		if cursor.TotalRows() != 25 {
+			t.Errorf("Expected TotalRows 25, got %d", cursor.TotalRows())
+		}
+		if cursor.Yielded() != count {
+			t.Errorf("Expected Yielded %d, got %d", count, cursor.Yielded())
+		}
+	}
+
+	if count != 25 {
+		t.Errorf("Expected 25 items, got %d", count)
+	}
+	if requests.Load() != 3 {
+		t.Errorf("Expected 3 page requests, got %d", requests.Load())
+	}
+}
+
+func TestItemsIteratorStopsAtTotalRows(t *testing.T) {
+	var failing atomic.Bool
+	var requests atomic.Int32
+	server := newPagingServer(t, 20, 0, &failing, &requests)
+	defer server.Close()
+
+	client := newIteratorTestClient(t, server.URL)
+
+	count := 0
+	for _, err := range client.Items(context.Background(), ItemsParams{}) {
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		count++
+	}
+
+	if count != 20 {
+		t.Errorf("Expected 20 items, got %d", count)
+	}
+	// The second page is full but TotalRows says it is the last one
+	if requests.Load() != 2 {
+		t.Errorf("Expected 2 page requests, got %d", requests.Load())
+	}
+}
+
+func TestItemsIteratorEarlyBreak(t *testing.T) {
+	var failing atomic.Bool
+	var requests atomic.Int32
+	server := newPagingServer(t, 25, 0, &failing, &requests)
+	defer server.Close()
+
+	client := newIteratorTestClient(t, server.URL)
+
+	count := 0
+	for range client.Items(context.Background(), ItemsParams{}) {
+		count++
+		if count == 5 {
+			break
+		}
+	}
+
+	if requests.Load() != 1 {
+		t.Errorf("Expected only the first page to be fetched, got %d requests", requests.Load())
+	}
+}
+
+func TestItemCursorCancellation(t *testing.T) {
+	var failing atomic.Bool
+	var requests atomic.Int32
+	server := newPagingServer(t, 25, 0, &failing, &requests)
+	defer server.Close()
+
+	client := newIteratorTestClient(t, server.URL)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cursor := client.NewItemCursor(ItemsParams{})
+	count := 0
+	for cursor.Next(ctx) {
+		count++
+		if count == 10 {
+			cancel()
+		}
+	}
+
+	if count != 10 {
+		t.Errorf("Expected 10 items before cancellation, got %d", count)
+	}
+	if !errors.Is(cursor.Err(), context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", cursor.Err())
+	}
+	if requests.Load() != 1 {
+		t.Errorf("Expected no page fetched after cancellation, got %d requests", requests.Load())
+	}
+}
+
+func TestItemCursorResume(t *testing.T) {
+	var failing atomic.Bool
+	var requests atomic.Int32
+	failing.Store(true)
+	server := newPagingServer(t, 25, 2, &failing, &requests)
+	defer server.Close()
+
+	client := newIteratorTestClient(t, server.URL)
+
+	var seen []string
+	cursor := client.NewItemCursor(ItemsParams{})
+	for cursor.Next(context.Background()) {
+		seen = append(seen, cursor.Item().ID)
+	}
+
+	var pageErr *PageError
+	if !errors.As(cursor.Err(), &pageErr) {
+		t.Fatalf("Expected *PageError, got %v", cursor.Err())
+	}
+	if pageErr.Page != 2 || cursor.NextPage() != 2 {
+		t.Errorf("Expected failure on page 2, got page %d (next %d)", pageErr.Page, cursor.NextPage())
+	}
+	if len(seen) != 10 {
+		t.Errorf("Expected 10 items before the failure, got %d", len(seen))
+	}
+
+	// Resume from the failed page once the server recovers
+	failing.Store(false)
+	for item, err := range client.Items(context.Background(), ItemsParams{PageNumber: pageErr.Page}) {
+		if err != nil {
+			t.Fatalf("Unexpected error after resume: %v", err)
+		}
+		seen = append(seen, item.ID)
+	}
+
+	if len(seen) != 25 {
+		t.Fatalf("Expected 25 items after resume, got %d", len(seen))
+	}
+	for i, id := range seen {
+		if id != strconv.Itoa(i) {
+			t.Errorf("Expected item %d at position %d, got %s", i, i, id)
+		}
+	}
+}
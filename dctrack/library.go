@@ -0,0 +1,183 @@
+package dctrack
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// ListMakes retrieves the manufacturers in the DCTrack models library,
+// sorted by name
+func (c *Client) ListMakes(ctx context.Context) ([]Make, error) {
+	records, err := c.getRecordList(ctx, "makes", c.config.URL+"/makes", "makes")
+	if err != nil {
+		return nil, fmt.Errorf("error listing makes: %w", err)
+	}
+
+	makes := make([]Make, 0, len(records))
+	for _, record := range records {
+		makes = append(makes, Make{
+			ID:   recordString(record, "makeId"),
+			Name: recordString(record, "makeName"),
+		})
+	}
+	sort.Slice(makes, func(i, j int) bool {
+		return strings.ToLower(makes[i].Name) < strings.ToLower(makes[j].Name)
+	})
+
+	c.logger.Info("Fetched makes from DCTrack", zap.Int("count", len(makes)))
+	return makes, nil
+}
+
+// ListModels retrieves the models of a make, compared case-insensitively,
+// sorted by model name
+func (c *Client) ListModels(ctx context.Context, make string) ([]ModelDefinition, error) {
+	if make == "" {
+		return nil, fmt.Errorf("error listing models: empty make")
+	}
+
+	modelsURL := c.config.URL + "/models?make=" + url.QueryEscape(make)
+	records, err := c.getRecordList(ctx, "models", modelsURL, "models")
+	if err != nil {
+		return nil, fmt.Errorf("error listing models of %s: %w", make, err)
+	}
+
+	var models []ModelDefinition
+	for _, record := range records {
+		model := modelFromRecord(record)
+		if strings.EqualFold(model.Make, make) {
+			models = append(models, model)
+		}
+	}
+	sort.Slice(models, func(i, j int) bool {
+		return strings.ToLower(models[i].Model) < strings.ToLower(models[j].Model)
+	})
+
+	c.logger.Info("Fetched models from DCTrack",
+		zap.String("make", make),
+		zap.Int("count", len(models)))
+	return models, nil
+}
+
+// GetModel retrieves a single model by its library ID
+func (c *Client) GetModel(ctx context.Context, id string) (*ModelDefinition, error) {
+	if id == "" {
+		return nil, fmt.Errorf("error getting model: empty model ID")
+	}
+
+	record, err := c.sendItemRequest(ctx, "model", "GET", c.config.URL+"/models/"+url.PathEscape(id), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting model %s: %w", id, err)
+	}
+	if wrapped, ok := record["model"].(map[string]interface{}); ok {
+		record = wrapped
+	}
+
+	model := modelFromRecord(record)
+	return &model, nil
+}
+
+// EnrichItems sets ModelSpec on items from the models library, fetching the
+// models of each make the items use once. Items whose model is not in the
+// library are left as they are.
+func (c *Client) EnrichItems(ctx context.Context, items []DCTrackItem) error {
+	library := NewModelLibrary(nil)
+	fetched := make(map[string]bool)
+	for _, item := range items {
+		key := strings.ToLower(item.Make)
+		if item.Make == "" || fetched[key] {
+			continue
+		}
+		fetched[key] = true
+
+		models, err := c.ListModels(ctx, item.Make)
+		if err != nil {
+			return err
+		}
+		library.Add(models...)
+	}
+
+	library.Enrich(items)
+	return nil
+}
+
+// ModelLibrary looks up model definitions by make and model name
+type ModelLibrary struct {
+	models map[string]ModelDefinition
+}
+
+// NewModelLibrary indexes models by make and model name
+func NewModelLibrary(models []ModelDefinition) *ModelLibrary {
+	library := &ModelLibrary{models: make(map[string]ModelDefinition, len(models))}
+	library.Add(models...)
+	return library
+}
+
+// Add indexes more models, replacing any with the same make and model
+func (l *ModelLibrary) Add(models ...ModelDefinition) {
+	for _, model := range models {
+		l.models[modelKey(model.Make, model.Model)] = model
+	}
+}
+
+// Lookup finds a model by make and model name, compared case-insensitively
+func (l *ModelLibrary) Lookup(make, model string) (ModelDefinition, bool) {
+	definition, ok := l.models[modelKey(make, model)]
+	return definition, ok
+}
+
+// Enrich sets ModelSpec on each item whose model is known, and fills a
+// missing RU height from the spec
+func (l *ModelLibrary) Enrich(items []DCTrackItem) {
+	for i := range items {
+		definition, ok := l.Lookup(items[i].Make, items[i].Model)
+		if !ok {
+			continue
+		}
+		items[i].ModelSpec = &definition
+		if items[i].Height == 0 {
+			items[i].Height = definition.RUHeight
+		}
+	}
+}
+
+// EffectivePower returns an item's effective power in watts, falling back
+// to its model's nameplate power when DCTrack reports none. Call
+// EnrichItems first for the fallback to apply.
+func EffectivePower(item DCTrackItem) float64 {
+	if item.TiEffectivePower > 0 || item.ModelSpec == nil {
+		return item.TiEffectivePower
+	}
+	return item.ModelSpec.NameplateWatts
+}
+
+// modelKey normalizes a make and model for lookup
+func modelKey(make, model string) string {
+	return strings.ToLower(strings.TrimSpace(make)) + "\x00" + strings.ToLower(strings.TrimSpace(model))
+}
+
+// modelFromRecord maps a models library record
+func modelFromRecord(record map[string]interface{}) ModelDefinition {
+	get := func(key string) string {
+		return recordString(record, key)
+	}
+
+	return ModelDefinition{
+		ID:             get("modelId"),
+		Make:           get("make"),
+		Model:          get("model"),
+		ItemClass:      get("class"),
+		Mounting:       get("mounting"),
+		RUHeight:       int(recordFloat(record, "ruHeight")),
+		NameplateWatts: recordFloat(record, "nameplateWatts"),
+		Weight:         recordFloat(record, "weight"),
+		Width:          recordFloat(record, "width"),
+		Depth:          recordFloat(record, "depth"),
+		DataPorts:      int(recordFloat(record, "dataPortCount")),
+		PowerPorts:     int(recordFloat(record, "powerPortCount")),
+	}
+}
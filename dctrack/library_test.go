@@ -0,0 +1,108 @@
+package dctrack
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestModelsLibrary(t *testing.T) {
+	modelRequests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/authentication/login":
+			w.Header().Set("Authorization", "Bearer mock-token-12345")
+		case "/api/v2/makes":
+			w.Write([]byte(`[{"makeId":2,"makeName":"HPE"},{"makeId":1,"makeName":"Dell"},{"makeId":3,"makeName":"cisco"}]`))
+		case "/api/v2/models":
+			modelRequests++
+			if r.URL.Query().Get("make") != "Dell" {
+				t.Errorf("Expected make Dell, got %s", r.URL.RawQuery)
+			}
+			// Server ignores the make filter
+			w.Write([]byte(`{"models":[
+				{"modelId":11,"make":"Dell","model":"PowerEdge R750","ruHeight":2,"nameplateWatts":1400,"dataPortCount":4,"powerPortCount":2},
+				{"modelId":10,"make":"Dell","model":"PowerEdge R650","ruHeight":"1","nameplateWatts":"1100"},
+				{"modelId":20,"make":"HPE","model":"DL380"}
+			]}`))
+		case "/api/v2/models/11":
+			w.Write([]byte(`{"model":{"modelId":11,"make":"Dell","model":"PowerEdge R750","class":"Device","ruHeight":2,"nameplateWatts":1400,"weight":28.6}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(server.URL+"/api/v2", "testuser", "testpass")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx := context.Background()
+
+	makes, err := client.ListMakes(ctx)
+	if err != nil {
+		t.Fatalf("ListMakes failed: %v", err)
+	}
+	if len(makes) != 3 || makes[0].Name != "cisco" || makes[1] != (Make{ID: "1", Name: "Dell"}) {
+		t.Errorf("Expected makes sorted by name, got %+v", makes)
+	}
+
+	models, err := client.ListModels(ctx, "Dell")
+	if err != nil {
+		t.Fatalf("ListModels failed: %v", err)
+	}
+	if len(models) != 2 || models[0].Model != "PowerEdge R650" || models[0].RUHeight != 1 || models[0].NameplateWatts != 1100 {
+		t.Errorf("Expected the two Dell models sorted by name, got %+v", models)
+	}
+	if models[1].DataPorts != 4 || models[1].PowerPorts != 2 {
+		t.Errorf("Expected port counts on the R750, got %+v", models[1])
+	}
+
+	model, err := client.GetModel(ctx, "11")
+	if err != nil {
+		t.Fatalf("GetModel failed: %v", err)
+	}
+	if model.ItemClass != "Device" || model.Weight != 28.6 || model.Make != "Dell" {
+		t.Errorf("Unexpected model %+v", model)
+	}
+	if _, err := client.GetModel(ctx, "99"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for an unknown model, got %v", err)
+	}
+
+	items := []DCTrackItem{
+		{ID: "1", Make: "Dell", Model: "PowerEdge R750", TiEffectivePower: 600},
+		{ID: "2", Make: "DELL", Model: "poweredge r650"},
+		{ID: "3", Make: "Dell", Model: "PowerEdge R999"},
+		{ID: "4"},
+	}
+	if err := client.EnrichItems(ctx, items); err != nil {
+		t.Fatalf("EnrichItems failed: %v", err)
+	}
+	if modelRequests != 2 {
+		t.Errorf("Expected the models of Dell to be fetched once more, got %d requests", modelRequests)
+	}
+
+	tests := []struct {
+		id     string
+		power  float64
+		height int
+		spec   bool
+	}{
+		{"1", 600, 2, true},  // Measured power wins over nameplate
+		{"2", 1100, 1, true}, // Falls back to nameplate
+		{"3", 0, 0, false},   // Model not in the library
+		{"4", 0, 0, false},   // No make
+	}
+	for i, tt := range tests {
+		item := items[i]
+		if got := EffectivePower(item); got != tt.power || item.Height != tt.height || (item.ModelSpec != nil) != tt.spec {
+			t.Errorf("Item %s: expected power %g, height %d, spec %v; got %g, %d, %v",
+				tt.id, tt.power, tt.height, tt.spec, got, item.Height, item.ModelSpec != nil)
+		}
+		if got, _ := PowerOf(item, PowerConsumption); got != tt.power {
+			t.Errorf("Item %s: expected consumption %g, got %g", tt.id, tt.power, got)
+		}
+	}
+}
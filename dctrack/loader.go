@@ -0,0 +1,462 @@
+package dctrack
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// defaultsProfile is the optional section merged into every profile
+	defaultsProfile = "defaults"
+	// profilesKey holds the profile sections of a multi-environment file
+	profilesKey = "profiles"
+	// flatProfile names the single profile of a file without profiles
+	flatProfile = "default"
+)
+
+// ConfigError reports an invalid configuration value. Key names the
+// offending setting as "profile.key" for file values or the variable name
+// for environment overrides.
+type ConfigError struct {
+	Key string
+	Err error
+}
+
+func (e *ConfigError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Key, e.Err)
+}
+
+func (e *ConfigError) Unwrap() error {
+	return e.Err
+}
+
+// LoadConfig reads a multi-environment YAML or JSON file in the format of
+// the repository's config.yaml and returns the named profile.
+//
+// Values are layered in this order: built-in defaults, the optional
+// "defaults" section, profiles named by "extends" (base first), the profile
+// itself, and finally DCTRACK_* environment variables. When profile is
+// empty DCTRACK_PROFILE is used.
+//
+// Profile sections sit at the top level of the file, or under a top-level
+// "profiles" key with only the "defaults" section beside it. A file with a
+// top-level url holds the settings of a single profile named "default".
+func LoadConfig(path, profile string) (Config, error) {
+	profiles, flat, err := readProfiles(path)
+	if err != nil {
+		return Config{}, err
+	}
+
+	if profile == "" {
+		profile = os.Getenv("DCTRACK_PROFILE")
+	}
+	if profile == "" && flat {
+		profile = flatProfile
+	}
+	if profile == "" {
+		return Config{}, fmt.Errorf("no profile selected in %s (available: %s)", path, profileNames(profiles))
+	}
+	if _, ok := profiles[profile]; !ok || profile == defaultsProfile {
+		return Config{}, fmt.Errorf("profile %q not found in %s (available: %s)", profile, path, profileNames(profiles))
+	}
+
+	chain, err := profileChain(profiles, profile)
+	if err != nil {
+		return Config{}, err
+	}
+
+	config := DefaultConfig("", "", "")
+	if defaults, ok := profiles[defaultsProfile]; ok {
+		if err := applyProfile(&config, defaultsProfile, defaults); err != nil {
+			return Config{}, err
+		}
+	}
+	for _, name := range chain {
+		if err := applyProfile(&config, name, profiles[name]); err != nil {
+			return Config{}, err
+		}
+	}
+
+	if err := ApplyEnvOverrides(&config); err != nil {
+		return Config{}, err
+	}
+
+	if err := validateLoadedConfig(&config, profile); err != nil {
+		return Config{}, err
+	}
+
+	return config, nil
+}
+
+// ApplyEnvOverrides overlays the DCTRACK_* environment variables used by
+// the dctrackcheck CLI onto config
+func ApplyEnvOverrides(config *Config) error {
+	stringVars := map[string]*string{
+		"DCTRACK_URL":           &config.URL,
+		"DCTRACK_USERNAME":      &config.Username,
+		"DCTRACK_PASSWORD":      &config.Password,
+		"DCTRACK_PASSWORD_FILE": &config.PasswordFile,
+	}
+	for name, field := range stringVars {
+		if value := os.Getenv(name); value != "" {
+			*field = value
+		}
+	}
+
+	intVars := map[string]*int{
+		"DCTRACK_PAGE_SIZE":   &config.PageSize,
+		"DCTRACK_MAX_RETRIES": &config.MaxRetries,
+	}
+	for name, field := range intVars {
+		if value := os.Getenv(name); value != "" {
+			i, err := strconv.Atoi(value)
+			if err != nil {
+				return &ConfigError{Key: name, Err: fmt.Errorf("expected an integer, got %q", value)}
+			}
+			*field = i
+		}
+	}
+
+	boolVars := map[string]*bool{
+		"DCTRACK_VERIFY_SSL": &config.VerifySSL,
+		"DCTRACK_ALL_FIELDS": &config.RequestAllFields,
+	}
+	for name, field := range boolVars {
+		if value := os.Getenv(name); value != "" {
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return &ConfigError{Key: name, Err: fmt.Errorf("expected a boolean, got %q", value)}
+			}
+			*field = b
+		}
+	}
+
+	if value := os.Getenv("DCTRACK_RETRY_DELAY"); value != "" {
+		d, err := parseDuration(value)
+		if err != nil {
+			return &ConfigError{Key: "DCTRACK_RETRY_DELAY", Err: err}
+		}
+		config.RetryDelay = d
+	}
+
+	return nil
+}
+
+// readProfiles decodes the file into raw per-profile maps, including the
+// defaults section when present, reporting whether it was a flat
+// single-profile file
+func readProfiles(path string) (map[string]map[string]interface{}, bool, error) {
+	expanded, err := ExpandHome(path)
+	if err != nil {
+		return nil, false, err
+	}
+
+	content, err := os.ReadFile(expanded)
+	if err != nil {
+		return nil, false, fmt.Errorf("error reading config file: %w", err)
+	}
+
+	var raw map[string]interface{}
+	if strings.EqualFold(filepath.Ext(expanded), ".json") {
+		if err := json.Unmarshal(content, &raw); err != nil {
+			return nil, false, fmt.Errorf("error parsing %s: %w", path, err)
+		}
+	} else {
+		if err := yaml.Unmarshal(content, &raw); err != nil {
+			return nil, false, fmt.Errorf("error parsing %s: %w", path, err)
+		}
+	}
+
+	wrapped, ok := raw[profilesKey]
+	if !ok {
+		// A top-level url means the file describes a single environment
+		if _, ok := raw["url"]; ok {
+			return map[string]map[string]interface{}{flatProfile: raw}, true, nil
+		}
+		profiles, err := profileSections(raw, "")
+		return profiles, false, err
+	}
+	sections, ok := wrapped.(map[string]interface{})
+	if !ok {
+		return nil, false, &ConfigError{Key: profilesKey, Err: fmt.Errorf("expected a mapping of profiles")}
+	}
+
+	profiles := make(map[string]map[string]interface{}, len(sections)+1)
+	for name, value := range raw {
+		switch name {
+		case profilesKey:
+		case defaultsProfile:
+			section, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, false, &ConfigError{Key: name, Err: fmt.Errorf("expected a mapping of settings")}
+			}
+			profiles[name] = section
+		default:
+			return nil, false, &ConfigError{Key: name, Err: fmt.Errorf("settings belong in a profile under %q", profilesKey)}
+		}
+	}
+	if _, ok := sections[defaultsProfile]; ok {
+		return nil, false, &ConfigError{Key: profilesKey + "." + defaultsProfile, Err: fmt.Errorf("%q belongs beside %q", defaultsProfile, profilesKey)}
+	}
+	nested, err := profileSections(sections, profilesKey+".")
+	if err != nil {
+		return nil, false, err
+	}
+	for name, section := range nested {
+		profiles[name] = section
+	}
+
+	return profiles, false, nil
+}
+
+// profileSections checks that every value of sections is a mapping of
+// settings, naming offenders with prefix
+func profileSections(sections map[string]interface{}, prefix string) (map[string]map[string]interface{}, error) {
+	profiles := make(map[string]map[string]interface{}, len(sections))
+	for name, value := range sections {
+		section, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, &ConfigError{Key: prefix + name, Err: fmt.Errorf("expected a mapping of settings")}
+		}
+		profiles[name] = section
+	}
+	return profiles, nil
+}
+
+// profileChain resolves "extends" links, returning profile names base first
+func profileChain(profiles map[string]map[string]interface{}, profile string) ([]string, error) {
+	var chain []string
+	seen := make(map[string]bool)
+
+	for name := profile; name != ""; {
+		if seen[name] {
+			return nil, &ConfigError{Key: name + ".extends", Err: fmt.Errorf("inheritance cycle through %q", name)}
+		}
+		seen[name] = true
+		chain = append([]string{name}, chain...)
+
+		parent, ok := profiles[name]["extends"]
+		if !ok {
+			break
+		}
+		parentName, ok := parent.(string)
+		if !ok {
+			return nil, &ConfigError{Key: name + ".extends", Err: fmt.Errorf("expected a profile name")}
+		}
+		if _, ok := profiles[parentName]; !ok {
+			return nil, &ConfigError{Key: name + ".extends", Err: fmt.Errorf("unknown profile %q", parentName)}
+		}
+		name = parentName
+	}
+
+	return chain, nil
+}
+
+// applyProfile overlays the settings of one profile onto config
+func applyProfile(config *Config, profile string, settings map[string]interface{}) error {
+	keys := make([]string, 0, len(settings))
+	for key := range settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := settings[key]
+		var err error
+
+		switch key {
+		case "extends":
+			continue
+		case "url":
+			config.URL, err = stringValue(value)
+		case "username":
+			config.Username, err = stringValue(value)
+		case "password":
+			config.Password, err = stringValue(value)
+		case "password_file":
+			config.PasswordFile, err = stringValue(value)
+		case "page_size":
+			config.PageSize, err = intValue(value)
+		case "max_retries":
+			config.MaxRetries, err = intValue(value)
+		case "retry_delay":
+			config.RetryDelay, err = durationValue(value)
+		case "token_refresh_margin":
+			config.TokenRefreshMargin, err = durationValue(value)
+		case "verify_ssl":
+			config.VerifySSL, err = boolValue(value)
+		case "request_all_fields":
+			config.RequestAllFields, err = boolValue(value)
+		case "concurrency":
+			config.Concurrency, err = intValue(value)
+		case "rate_limit":
+			config.RateLimit, err = floatValue(value)
+		case "strict_mapping":
+			config.StrictMapping, err = boolValue(value)
+		case "custom_fields":
+			config.CustomFields, err = stringListValue(value)
+		case "dry_run":
+			config.DryRun, err = boolValue(value)
+		default:
+			err = fmt.Errorf("unknown setting")
+		}
+
+		if err != nil {
+			return &ConfigError{Key: profile + "." + key, Err: err}
+		}
+	}
+
+	return nil
+}
+
+// validateLoadedConfig checks the merged profile, naming the key at fault
+func validateLoadedConfig(config *Config, profile string) error {
+	if config.URL == "" {
+		return &ConfigError{Key: profile + ".url", Err: fmt.Errorf("is required")}
+	}
+	if u, err := url.Parse(config.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return &ConfigError{Key: profile + ".url", Err: fmt.Errorf("must be an http(s) URL, got %q", config.URL)}
+	}
+	if config.Username == "" {
+		return &ConfigError{Key: profile + ".username", Err: fmt.Errorf("is required")}
+	}
+	if config.Password == "" && config.PasswordFile == "" {
+		return &ConfigError{Key: profile + ".password_file", Err: fmt.Errorf("password or password_file is required")}
+	}
+	if config.PageSize <= 0 {
+		return &ConfigError{Key: profile + ".page_size", Err: fmt.Errorf("must be positive, got %d", config.PageSize)}
+	}
+	if config.MaxRetries < 0 {
+		return &ConfigError{Key: profile + ".max_retries", Err: fmt.Errorf("must not be negative, got %d", config.MaxRetries)}
+	}
+	if config.RetryDelay < 0 {
+		return &ConfigError{Key: profile + ".retry_delay", Err: fmt.Errorf("must not be negative, got %s", config.RetryDelay)}
+	}
+	return config.Validate()
+}
+
+func profileNames(profiles map[string]map[string]interface{}) string {
+	var names []string
+	for name := range profiles {
+		if name != defaultsProfile {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+func stringValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	}
+	return "", fmt.Errorf("expected a string, got %v", value)
+}
+
+// stringListValue accepts a list of strings or a comma-separated string
+func stringListValue(value interface{}) ([]string, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		var list []string
+		for _, entry := range strings.Split(v, ",") {
+			if entry = strings.TrimSpace(entry); entry != "" {
+				list = append(list, entry)
+			}
+		}
+		return list, nil
+	case []interface{}:
+		list := make([]string, 0, len(v))
+		for _, entry := range v {
+			text, ok := entry.(string)
+			if !ok {
+				return nil, fmt.Errorf("expected a list of strings, got %v", value)
+			}
+			list = append(list, text)
+		}
+		return list, nil
+	}
+	return nil, fmt.Errorf("expected a list of strings, got %v", value)
+}
+
+func intValue(value interface{}) (int, error) {
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case float64:
+		// JSON numbers decode as float64
+		if v == float64(int(v)) {
+			return int(v), nil
+		}
+	case string:
+		if i, err := strconv.Atoi(v); err == nil {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("expected an integer, got %v", value)
+}
+
+func floatValue(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	case string:
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return f, nil
+		}
+	}
+	return 0, fmt.Errorf("expected a number, got %v", value)
+}
+
+func boolValue(value interface{}) (bool, error) {
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b, nil
+		}
+	}
+	return false, fmt.Errorf("expected a boolean, got %v", value)
+}
+
+// durationValue accepts Go duration strings ("1s", "500ms") or a number of seconds
+func durationValue(value interface{}) (time.Duration, error) {
+	switch v := value.(type) {
+	case string:
+		return parseDuration(v)
+	case int:
+		return time.Duration(v) * time.Second, nil
+	case float64:
+		return time.Duration(v * float64(time.Second)), nil
+	}
+	return 0, fmt.Errorf("expected a duration such as \"1s\", got %v", value)
+}
+
+// parseDuration accepts Go duration strings or a bare number of seconds,
+// which is what DCTRACK_RETRY_DELAY has always meant to the CLI
+func parseDuration(value string) (time.Duration, error) {
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		return time.Duration(seconds * float64(time.Second)), nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("expected a duration such as \"1s\", got %q", value)
+	}
+	return d, nil
+}
@@ -0,0 +1,342 @@
+package dctrack
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// clearDCTrackEnv unsets the variables LoadConfig consults for the duration of a test
+func clearDCTrackEnv(t *testing.T) {
+	t.Helper()
+	for _, name := range []string{
+		"DCTRACK_URL", "DCTRACK_USERNAME", "DCTRACK_PASSWORD", "DCTRACK_PASSWORD_FILE",
+		"DCTRACK_PAGE_SIZE", "DCTRACK_MAX_RETRIES", "DCTRACK_RETRY_DELAY",
+		"DCTRACK_VERIFY_SSL", "DCTRACK_ALL_FIELDS", "DCTRACK_PROFILE",
+	} {
+		t.Setenv(name, "")
+		os.Unsetenv(name)
+	}
+}
+
+func writeConfigFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	return path
+}
+
+func TestLoadConfigShippedFile(t *testing.T) {
+	clearDCTrackEnv(t)
+
+	tests := []struct {
+		profile    string
+		url        string
+		pageSize   int
+		maxRetries int
+		retryDelay time.Duration
+		verifySSL  bool
+		allFields  bool
+	}{
+		{"integration", "https://dctrack-dev.company.com/api/v2", 100, 3, time.Second, false, false},
+		{"production", "https://dctrack.company.com/api/v2", 1000, 5, 2 * time.Second, true, true},
+		{"local", "https://dctrack.corp.redhat.com/api/v2", 1000, 3, time.Second, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.profile, func(t *testing.T) {
+			config, err := LoadConfig("../config.yaml", tt.profile)
+			if err != nil {
+				t.Fatalf("Failed to load profile: %v", err)
+			}
+
+			if config.URL != tt.url {
+				t.Errorf("Expected URL %s, got %s", tt.url, config.URL)
+			}
+			if config.Username != "api-pwo" {
+				t.Errorf("Expected Username api-pwo, got %s", config.Username)
+			}
+			if config.PasswordFile != "~/.secrets/on-premise-asset-hub-secrets/dctrack_password.txt" {
+				t.Errorf("Unexpected PasswordFile %s", config.PasswordFile)
+			}
+			if config.PageSize != tt.pageSize {
+				t.Errorf("Expected PageSize %d, got %d", tt.pageSize, config.PageSize)
+			}
+			if config.MaxRetries != tt.maxRetries {
+				t.Errorf("Expected MaxRetries %d, got %d", tt.maxRetries, config.MaxRetries)
+			}
+			if config.RetryDelay != tt.retryDelay {
+				t.Errorf("Expected RetryDelay %v, got %v", tt.retryDelay, config.RetryDelay)
+			}
+			if config.VerifySSL != tt.verifySSL {
+				t.Errorf("Expected VerifySSL %t, got %t", tt.verifySSL, config.VerifySSL)
+			}
+			if config.RequestAllFields != tt.allFields {
+				t.Errorf("Expected RequestAllFields %t, got %t", tt.allFields, config.RequestAllFields)
+			}
+		})
+	}
+}
+
+func TestLoadConfigInheritance(t *testing.T) {
+	clearDCTrackEnv(t)
+
+	path := writeConfigFile(t, "config.yaml", `
+defaults:
+  username: "api-user"
+  password_file: "~/.secrets/dctrack_password.txt"
+  max_retries: 4
+
+profiles:
+  production:
+    url: "https://dctrack.company.com/api/v2"
+    page_size: 1000
+    retry_delay: "2s"
+
+  staging:
+    extends: production
+    url: "https://dctrack-stage.company.com/api/v2"
+    retry_delay: "500ms"
+`)
+
+	config, err := LoadConfig(path, "staging")
+	if err != nil {
+		t.Fatalf("Failed to load profile: %v", err)
+	}
+
+	if config.URL != "https://dctrack-stage.company.com/api/v2" {
+		t.Errorf("Expected staging URL, got %s", config.URL)
+	}
+	if config.Username != "api-user" {
+		t.Errorf("Expected Username from defaults, got %s", config.Username)
+	}
+	if config.PageSize != 1000 {
+		t.Errorf("Expected PageSize inherited from production, got %d", config.PageSize)
+	}
+	if config.MaxRetries != 4 {
+		t.Errorf("Expected MaxRetries from defaults, got %d", config.MaxRetries)
+	}
+	if config.RetryDelay != 500*time.Millisecond {
+		t.Errorf("Expected RetryDelay 500ms, got %v", config.RetryDelay)
+	}
+	if !config.VerifySSL {
+		t.Errorf("Expected built-in VerifySSL default true")
+	}
+}
+
+func TestLoadConfigTopLevelSections(t *testing.T) {
+	clearDCTrackEnv(t)
+
+	path := writeConfigFile(t, "config.yaml", `
+defaults:
+  username: "api-user"
+  password_file: "~/.secrets/dctrack_password.txt"
+
+integration:
+  url: "https://dctrack-dev.company.com/api/v2"
+  page_size: 100
+
+production:
+  url: "https://dctrack.company.com/api/v2"
+  page_size: 1000
+
+staging:
+  extends: production
+  url: "https://dctrack-stage.company.com/api/v2"
+`)
+
+	config, err := LoadConfig(path, "production")
+	if err != nil {
+		t.Fatalf("Failed to load profile: %v", err)
+	}
+	if config.URL != "https://dctrack.company.com/api/v2" || config.PageSize != 1000 {
+		t.Errorf("Expected the production section, got %s with PageSize %d", config.URL, config.PageSize)
+	}
+	if config.Username != "api-user" {
+		t.Errorf("Expected Username from defaults, got %s", config.Username)
+	}
+
+	config, err = LoadConfig(path, "staging")
+	if err != nil {
+		t.Fatalf("Failed to load profile: %v", err)
+	}
+	if config.URL != "https://dctrack-stage.company.com/api/v2" || config.PageSize != 1000 {
+		t.Errorf("Expected staging inheriting from production, got %s with PageSize %d", config.URL, config.PageSize)
+	}
+
+	bad := writeConfigFile(t, "bad.yaml", "production:\n  url: https://x.example.com\npage_size: 10\n")
+	var configErr *ConfigError
+	if _, err := LoadConfig(bad, "production"); !errors.As(err, &configErr) || configErr.Key != "page_size" {
+		t.Errorf("Expected an error for page_size beside the sections, got %v", err)
+	}
+}
+
+func TestLoadConfigEnvOverrides(t *testing.T) {
+	clearDCTrackEnv(t)
+	t.Setenv("DCTRACK_PROFILE", "production")
+	t.Setenv("DCTRACK_PAGE_SIZE", "250")
+	t.Setenv("DCTRACK_RETRY_DELAY", "3")
+	t.Setenv("DCTRACK_VERIFY_SSL", "false")
+
+	config, err := LoadConfig("../config.yaml", "")
+	if err != nil {
+		t.Fatalf("Failed to load profile: %v", err)
+	}
+
+	if config.URL != "https://dctrack.company.com/api/v2" {
+		t.Errorf("Expected production URL from DCTRACK_PROFILE, got %s", config.URL)
+	}
+	if config.PageSize != 250 {
+		t.Errorf("Expected PageSize 250, got %d", config.PageSize)
+	}
+	if config.RetryDelay != 3*time.Second {
+		t.Errorf("Expected RetryDelay 3s, got %v", config.RetryDelay)
+	}
+	if config.VerifySSL {
+		t.Errorf("Expected VerifySSL false")
+	}
+}
+
+func TestLoadConfigJSON(t *testing.T) {
+	clearDCTrackEnv(t)
+
+	path := writeConfigFile(t, "config.json", `{
+		"profiles": {
+			"production": {
+				"url": "https://dctrack.company.com/api/v2",
+				"username": "api-user",
+				"password": "secret",
+				"page_size": 500,
+				"retry_delay": "1500ms",
+				"custom_fields": ["Audit Date", "Cost Center"]
+			}
+		}
+	}`)
+
+	config, err := LoadConfig(path, "production")
+	if err != nil {
+		t.Fatalf("Failed to load profile: %v", err)
+	}
+	if config.PageSize != 500 {
+		t.Errorf("Expected PageSize 500, got %d", config.PageSize)
+	}
+	if config.RetryDelay != 1500*time.Millisecond {
+		t.Errorf("Expected RetryDelay 1.5s, got %v", config.RetryDelay)
+	}
+	if len(config.CustomFields) != 2 || config.CustomFields[1] != "Cost Center" {
+		t.Errorf("Expected two custom fields, got %v", config.CustomFields)
+	}
+}
+
+func TestLoadConfigFlatFile(t *testing.T) {
+	clearDCTrackEnv(t)
+
+	// Without a profiles key, sections are not guessed at: a mapping at the
+	// top level is an unknown setting of the single profile
+	path := writeConfigFile(t, "config.yaml", `
+url: "https://dctrack.company.com/api/v2"
+username: "api-user"
+password: "secret"
+production:
+  page_size: 1000
+`)
+
+	_, err := LoadConfig(path, "")
+	var configErr *ConfigError
+	if !errors.As(err, &configErr) || configErr.Key != "default.production" {
+		t.Errorf("Expected an error for default.production, got %v", err)
+	}
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	clearDCTrackEnv(t)
+
+	tests := []struct {
+		name    string
+		content string
+		profile string
+		env     map[string]string
+		key     string
+	}{
+		{
+			name:    "bad duration",
+			content: "profiles:\n  prod:\n    url: https://x.example.com\n    username: u\n    password: p\n    retry_delay: soon\n",
+			profile: "prod",
+			key:     "prod.retry_delay",
+		},
+		{
+			name:    "bad integer",
+			content: "profiles:\n  prod:\n    url: https://x.example.com\n    username: u\n    password: p\n    page_size: lots\n",
+			profile: "prod",
+			key:     "prod.page_size",
+		},
+		{
+			name:    "unknown key",
+			content: "profiles:\n  prod:\n    url: https://x.example.com\n    username: u\n    password: p\n    verify_sll: false\n",
+			profile: "prod",
+			key:     "prod.verify_sll",
+		},
+		{
+			name:    "missing url",
+			content: "profiles:\n  prod:\n    username: u\n    password: p\n",
+			profile: "prod",
+			key:     "prod.url",
+		},
+		{
+			name:    "missing password",
+			content: "profiles:\n  prod:\n    url: https://x.example.com\n    username: u\n",
+			profile: "prod",
+			key:     "prod.password_file",
+		},
+		{
+			name:    "inheritance cycle",
+			content: "profiles:\n  a:\n    extends: b\n  b:\n    extends: a\n",
+			profile: "a",
+			key:     "a.extends",
+		},
+		{
+			name:    "key beside profiles",
+			content: "profiles:\n  prod:\n    url: https://x.example.com\npage_size: 10\n",
+			profile: "prod",
+			key:     "page_size",
+		},
+		{
+			name:    "bad env override",
+			content: "profiles:\n  prod:\n    url: https://x.example.com\n    username: u\n    password: p\n",
+			profile: "prod",
+			env:     map[string]string{"DCTRACK_MAX_RETRIES": "many"},
+			key:     "DCTRACK_MAX_RETRIES",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+
+			_, err := LoadConfig(writeConfigFile(t, "config.yaml", tt.content), tt.profile)
+			if err == nil {
+				t.Fatalf("Expected error but got none")
+			}
+
+			var configErr *ConfigError
+			if !errors.As(err, &configErr) {
+				t.Fatalf("Expected *ConfigError, got %T: %v", err, err)
+			}
+			if configErr.Key != tt.key {
+				t.Errorf("Expected error for key %s, got %s (%v)", tt.key, configErr.Key, err)
+			}
+		})
+	}
+
+	t.Run("unknown profile", func(t *testing.T) {
+		if _, err := LoadConfig("../config.yaml", "staging"); err == nil {
+			t.Errorf("Expected error for unknown profile")
+		}
+	})
+}
@@ -0,0 +1,379 @@
+package dctrack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// LocationLevel is the kind of place a location node represents
+type LocationLevel string
+
+const (
+	LevelSite     LocationLevel = "Site"
+	LevelBuilding LocationLevel = "Building"
+	LevelFloor    LocationLevel = "Floor"
+	LevelRoom     LocationLevel = "Room"
+	LevelRow      LocationLevel = "Row"
+	LevelCabinet  LocationLevel = "Cabinet"
+)
+
+// Location is one entry of the DCTrack locations endpoint
+type Location struct {
+	ID       string        `json:"id"`
+	Code     string        `json:"code"`
+	Name     string        `json:"name"`
+	Level    LocationLevel `json:"level"`
+	ParentID string        `json:"parent_id"`
+}
+
+// LocationNode is a location in a LocationTree
+type LocationNode struct {
+	Location
+	Parent   *LocationNode   `json:"-"`
+	Children []*LocationNode `json:"children,omitempty"`
+
+	ItemCount  int `json:"item_count"`  // Items assigned directly to this node
+	TotalItems int `json:"total_items"` // Items at this node and below
+}
+
+// LocationTree is the site → building → floor → room → row → cabinet
+// hierarchy
+type LocationTree struct {
+	Roots []*LocationNode `json:"roots"`
+}
+
+// ListLocations retrieves every location from the DCTrack locations endpoint
+func (c *Client) ListLocations(ctx context.Context) ([]Location, error) {
+	resp, err := c.doAuthorized(ctx, "locations", "GET", c.config.URL+"/locations", true, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error listing locations: %w", err)
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response body: %w", err)
+	}
+
+	records, err := decodeRecordList(bodyBytes, "locations")
+	if err != nil {
+		return nil, fmt.Errorf("error listing locations: %w", err)
+	}
+
+	locations := make([]Location, 0, len(records))
+	for _, record := range records {
+		locations = append(locations, locationFromRecord(record))
+	}
+
+	c.logger.Info("Fetched locations from DCTrack", zap.Int("count", len(locations)))
+	return locations, nil
+}
+
+// locationItemColumns are the item columns placing an item in the tree
+var locationItemColumns = []string{"cmbLocation", "tiFloorNodeCode", "tiFloorName",
+	"tiRoomNodeCode", "tiRoomName", "cmbRowLabel", "cmbCabinet"}
+
+// GetLocationTree builds the location hierarchy with item counts rolled
+// up. The hierarchy comes from the locations endpoint, or is synthesized
+// from item fields on servers without one or when it lists no locations.
+func (c *Client) GetLocationTree(ctx context.Context) (*LocationTree, error) {
+	locations, err := c.ListLocations(ctx)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+
+	items, err := c.GetItemsWithParams(ctx, ItemsParams{Columns: locationItemColumns})
+	if err != nil {
+		return nil, err
+	}
+
+	var tree *LocationTree
+	if len(locations) == 0 {
+		c.logger.Info("DCTrack listed no locations, building the tree from items")
+		tree = LocationTreeFromItems(items)
+	} else {
+		tree = NewLocationTree(locations)
+	}
+	tree.AddItems(items)
+	return tree, nil
+}
+
+// NewLocationTree links locations to their parents. Locations whose parent
+// is unknown become roots. Children are sorted by name.
+func NewLocationTree(locations []Location) *LocationTree {
+	nodes := make(map[string]*LocationNode, len(locations))
+	ordered := make([]*LocationNode, 0, len(locations))
+	for _, location := range locations {
+		node := &LocationNode{Location: location}
+		if location.ID != "" {
+			nodes[location.ID] = node
+		}
+		ordered = append(ordered, node)
+	}
+
+	tree := &LocationTree{}
+	for _, node := range ordered {
+		parent, ok := nodes[node.ParentID]
+		if !ok || node.ParentID == "" || parent == node {
+			tree.Roots = append(tree.Roots, node)
+			continue
+		}
+		node.Parent = parent
+		parent.Children = append(parent.Children, node)
+	}
+
+	tree.sort()
+	return tree
+}
+
+// LocationTreeFromItems synthesizes the hierarchy from the location, floor,
+// room, row and cabinet fields of items. Levels an item leaves blank are
+// skipped, and DCTrack does not report buildings on items.
+func LocationTreeFromItems(items []DCTrackItem) *LocationTree {
+	tree := &LocationTree{}
+	for _, item := range items {
+		if item.Location == "" {
+			continue
+		}
+
+		node := tree.child(nil, LevelSite, item.Location, item.Location)
+		for _, level := range itemLevels(item) {
+			if level.code != "" || level.name != "" {
+				node = tree.child(node, level.level, level.code, level.name)
+			}
+		}
+	}
+
+	tree.sort()
+	return tree
+}
+
+// child returns the child of parent (or root when parent is nil) with the
+// given level and code, creating it if needed
+func (t *LocationTree) child(parent *LocationNode, level LocationLevel, code, name string) *LocationNode {
+	if code == "" {
+		code = name
+	}
+	if name == "" {
+		name = code
+	}
+
+	siblings := t.Roots
+	if parent != nil {
+		siblings = parent.Children
+	}
+	for _, node := range siblings {
+		if node.Level == level && node.Code == code {
+			return node
+		}
+	}
+
+	node := &LocationNode{
+		Location: Location{Code: code, Name: name, Level: level},
+		Parent:   parent,
+	}
+	if parent == nil {
+		node.ID = code
+		t.Roots = append(t.Roots, node)
+	} else {
+		node.ID = parent.ID + "/" + code
+		node.ParentID = parent.ID
+		parent.Children = append(parent.Children, node)
+	}
+	return node
+}
+
+// Find returns the shallowest node whose code or name is key, compared
+// case-insensitively, or nil
+func (t *LocationTree) Find(key string) *LocationNode {
+	if matches := t.FindAll(key); len(matches) > 0 {
+		return matches[0]
+	}
+	return nil
+}
+
+// FindAll returns every node whose code or name is key, shallowest first.
+// Row and cabinet labels usually repeat between rooms.
+func (t *LocationTree) FindAll(key string) []*LocationNode {
+	var matches []*LocationNode
+	queue := append([]*LocationNode(nil), t.Roots...)
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		if node.matches(key) {
+			matches = append(matches, node)
+		}
+		queue = append(queue, node.Children...)
+	}
+	return matches
+}
+
+// Walk visits every node depth-first, parents before children, stopping
+// early if fn returns false
+func (t *LocationTree) Walk(fn func(node *LocationNode, depth int) bool) {
+	var walk func(nodes []*LocationNode, depth int) bool
+	walk = func(nodes []*LocationNode, depth int) bool {
+		for _, node := range nodes {
+			if !fn(node, depth) || !walk(node.Children, depth+1) {
+				return false
+			}
+		}
+		return true
+	}
+	walk(t.Roots, 0)
+}
+
+// AddItems assigns each item to the deepest node matching its location,
+// floor, room, row and cabinet, then recomputes the roll-up totals. Items
+// whose location is not in the tree are not counted.
+func (t *LocationTree) AddItems(items []DCTrackItem) {
+	sites := make(map[string]*LocationNode)
+	for _, item := range items {
+		site, ok := sites[item.Location]
+		if !ok {
+			site = t.findSite(item.Location)
+			sites[item.Location] = site
+		}
+		if site == nil {
+			continue
+		}
+
+		node := site
+		for _, level := range itemLevels(item) {
+			if below := node.findBelow(level.code, level.name); below != nil {
+				node = below
+			}
+		}
+		node.ItemCount++
+	}
+
+	var rollUp func(node *LocationNode) int
+	rollUp = func(node *LocationNode) int {
+		node.TotalItems = node.ItemCount
+		for _, child := range node.Children {
+			node.TotalItems += rollUp(child)
+		}
+		return node.TotalItems
+	}
+	for _, root := range t.Roots {
+		rollUp(root)
+	}
+}
+
+// findSite finds the node an item's cmbLocation refers to, preferring sites
+func (t *LocationTree) findSite(location string) *LocationNode {
+	if location == "" {
+		return nil
+	}
+	matches := t.FindAll(location)
+	for _, node := range matches {
+		if node.Level == LevelSite {
+			return node
+		}
+	}
+	if len(matches) > 0 {
+		return matches[0]
+	}
+	return nil
+}
+
+// Path returns the nodes from the root down to n
+func (n *LocationNode) Path() []*LocationNode {
+	var path []*LocationNode
+	for node := n; node != nil; node = node.Parent {
+		path = append([]*LocationNode{node}, path...)
+	}
+	return path
+}
+
+// PathString renders the path as "RDU2 > Floor 1 > Hall A"
+func (n *LocationNode) PathString() string {
+	names := make([]string, 0, 6)
+	for _, node := range n.Path() {
+		names = append(names, node.Name)
+	}
+	return strings.Join(names, " > ")
+}
+
+func (n *LocationNode) matches(key string) bool {
+	return key != "" && (strings.EqualFold(n.Code, key) || strings.EqualFold(n.Name, key))
+}
+
+// findBelow returns the shallowest descendant matching code or name
+func (n *LocationNode) findBelow(code, name string) *LocationNode {
+	if code == "" && name == "" {
+		return nil
+	}
+	queue := append([]*LocationNode(nil), n.Children...)
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		if node.matches(code) || node.matches(name) {
+			return node
+		}
+		queue = append(queue, node.Children...)
+	}
+	return nil
+}
+
+// sort orders every level of the tree by name
+func (t *LocationTree) sort() {
+	var sortNodes func(nodes []*LocationNode)
+	sortNodes = func(nodes []*LocationNode) {
+		sort.SliceStable(nodes, func(i, j int) bool {
+			return nodes[i].Name < nodes[j].Name
+		})
+		for _, node := range nodes {
+			sortNodes(node.Children)
+		}
+	}
+	sortNodes(t.Roots)
+}
+
+// itemLevel is one level of an item's location below the site
+type itemLevel struct {
+	level LocationLevel
+	code  string
+	name  string
+}
+
+// itemLevels returns an item's floor, room, row and cabinet, top down
+func itemLevels(item DCTrackItem) []itemLevel {
+	return []itemLevel{
+		{LevelFloor, item.TiFloorNodeCode, item.TiFloorName},
+		{LevelRoom, item.TiRoomNodeCode, item.TiRoomName},
+		{LevelRow, item.CmbRowLabel, item.CmbRowLabel},
+		{LevelCabinet, item.Cabinet, item.Cabinet},
+	}
+}
+
+// locationFromRecord maps a location record
+func locationFromRecord(record map[string]interface{}) Location {
+	location := Location{
+		ID:       recordString(record, "id"),
+		Code:     recordString(record, "code"),
+		Name:     recordString(record, "name"),
+		ParentID: recordString(record, "parentId"),
+	}
+	if location.Name == "" {
+		location.Name = location.Code
+	}
+
+	level := recordString(record, "type")
+	for _, known := range []LocationLevel{LevelSite, LevelBuilding, LevelFloor, LevelRoom, LevelRow, LevelCabinet} {
+		if strings.EqualFold(level, string(known)) {
+			location.Level = known
+			break
+		}
+	}
+	if location.Level == "" {
+		location.Level = LocationLevel(level)
+	}
+	return location
+}
@@ -0,0 +1,200 @@
+package dctrack
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// locationTestItems are spread over two rooms of RDU2 and one of RDU3
+var locationTestItems = []DCTrackItem{
+	{ID: "1", Location: "RDU2", TiFloorName: "Floor 1", TiRoomName: "Hall A", CmbRowLabel: "A", Cabinet: "CAB-A1"},
+	{ID: "2", Location: "RDU2", TiFloorName: "Floor 1", TiRoomName: "Hall A", CmbRowLabel: "A", Cabinet: "CAB-A2"},
+	{ID: "3", Location: "RDU2", TiFloorName: "Floor 1", TiRoomName: "Hall B", CmbRowLabel: "A", Cabinet: "CAB-B1"},
+	{ID: "4", Location: "RDU2", TiFloorName: "Floor 1", TiRoomName: "Hall B"},
+	{ID: "5", Location: "RDU3", TiRoomName: "Cage 7", Cabinet: "C7-01"},
+	{ID: "6", Location: "NOWHERE"},
+}
+
+func TestLocationTreeFromItems(t *testing.T) {
+	tree := LocationTreeFromItems(locationTestItems[:5])
+	tree.AddItems(locationTestItems)
+
+	if len(tree.Roots) != 2 || tree.Roots[0].Code != "RDU2" || tree.Roots[1].Code != "RDU3" {
+		t.Fatalf("Expected sites RDU2 and RDU3, got %+v", tree.Roots)
+	}
+
+	rdu2 := tree.Find("rdu2")
+	if rdu2 == nil || rdu2.TotalItems != 4 || rdu2.ItemCount != 0 {
+		t.Errorf("Expected 4 items rolled up to RDU2, got %+v", rdu2)
+	}
+
+	hallB := tree.Find("Hall B")
+	if hallB == nil || hallB.ItemCount != 1 || hallB.TotalItems != 2 {
+		t.Errorf("Expected Hall B with one direct and two total items, got %+v", hallB)
+	}
+	if got := hallB.PathString(); got != "RDU2 > Floor 1 > Hall B" {
+		t.Errorf("Unexpected path %s", got)
+	}
+
+	// RDU3 items have no floor or row, so the room hangs off the site
+	cage := tree.Find("C7-01")
+	if cage == nil || cage.Level != LevelCabinet || cage.Parent.Level != LevelRoom || cage.Parent.Parent != tree.Roots[1] {
+		t.Errorf("Expected cabinet under room under RDU3, got %+v", cage)
+	}
+
+	if rows := tree.FindAll("A"); len(rows) != 2 {
+		t.Errorf("Expected row A in two rooms, got %d", len(rows))
+	}
+	if tree.Find("missing") != nil {
+		t.Errorf("Expected no match for unknown key")
+	}
+
+	var visited []string
+	tree.Walk(func(node *LocationNode, depth int) bool {
+		visited = append(visited, node.Code)
+		return node.Code != "Hall A"
+	})
+	if len(visited) != 3 || visited[2] != "Hall A" {
+		t.Errorf("Expected walk to stop at Hall A, visited %v", visited)
+	}
+}
+
+func TestLocationTreeFromEndpoint(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{
+			name: "bare array",
+			body: `[
+				{"id":1,"code":"RDU2","name":"Raleigh 2","type":"SITE"},
+				{"id":2,"code":"B1","name":"Building 1","type":"Building","parentId":1},
+				{"id":3,"code":"F1","name":"Floor 1","type":"Floor","parentId":2},
+				{"id":4,"code":"HA","name":"Hall A","type":"Room","parentId":3}
+			]`,
+		},
+		{
+			name: "wrapped",
+			body: `{"locations":[
+				{"id":"4","code":"HA","name":"Hall A","type":"Room","parentId":"3"},
+				{"id":"3","code":"F1","name":"Floor 1","type":"Floor","parentId":"2"},
+				{"id":"2","code":"B1","name":"Building 1","type":"Building","parentId":"1"},
+				{"id":"1","code":"RDU2","name":"Raleigh 2","type":"Site"}
+			]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/api/v2/authentication/login":
+					w.Header().Set("Authorization", "Bearer mock-token-12345")
+				case "/api/v2/locations":
+					w.Write([]byte(tt.body))
+				case "/api/v2/quicksearch/items":
+					if r.URL.Query().Get("pageNumber") != "1" {
+						w.Write([]byte(`{"searchResults":{"items":[]}}`))
+						return
+					}
+					var payload quicksearchPayload
+					json.NewDecoder(r.Body).Decode(&payload)
+					payload.write(t, w, `[
+						{"id":"10","tiName":"web-01","cmbLocation":"RDU2","tiFloorName":"Floor 1","tiRoomName":"Hall A"},
+						{"id":"11","tiName":"web-02","cmbLocation":"RDU2","tiRoomNodeCode":"HA"},
+						{"id":"12","tiName":"web-03","cmbLocation":"RDU2"}
+					]`)
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			client, err := New(server.URL+"/api/v2", "testuser", "testpass", WithLimitedFields())
+			if err != nil {
+				t.Fatalf("Failed to create client: %v", err)
+			}
+
+			tree, err := client.GetLocationTree(context.Background())
+			if err != nil {
+				t.Fatalf("GetLocationTree failed: %v", err)
+			}
+
+			site := tree.Find("RDU2")
+			if site == nil || site.Level != LevelSite || site.Name != "Raleigh 2" {
+				t.Fatalf("Expected site RDU2, got %+v", site)
+			}
+			if site.TotalItems != 3 || site.ItemCount != 1 {
+				t.Errorf("Expected 3 items under RDU2 with 1 direct, got %d/%d", site.TotalItems, site.ItemCount)
+			}
+
+			// Items name no building; they are matched through it
+			hall := tree.Find("HA")
+			if hall == nil || hall.ItemCount != 2 || hall.PathString() != "Raleigh 2 > Building 1 > Floor 1 > Hall A" {
+				t.Errorf("Expected both room items in Hall A, got %+v", hall)
+			}
+		})
+	}
+}
+
+func TestGetLocationTreeWithoutEndpoint(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+	}{
+		{"not found", http.StatusNotFound, ""},
+		{"empty array", http.StatusOK, `[]`},
+		{"empty member", http.StatusOK, `{"locations":[]}`},
+		{"no member", http.StatusOK, `{}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			locationsListed := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/api/v2/authentication/login":
+					w.Header().Set("Authorization", "Bearer mock-token-12345")
+				case "/api/v2/quicksearch/items":
+					if r.URL.Query().Get("pageNumber") != "1" {
+						w.Write([]byte(`{"searchResults":{"items":[]}}`))
+						return
+					}
+					if locationsListed != 1 {
+						t.Errorf("Expected the locations endpoint to be tried before items, got %d calls", locationsListed)
+					}
+					var payload quicksearchPayload
+					json.NewDecoder(r.Body).Decode(&payload)
+					payload.write(t, w, `[
+						{"id":"1","tiName":"web-01","cmbLocation":"RDU2","tiRoomName":"Hall A"},
+						{"id":"2","tiName":"web-02","cmbLocation":"RDU2","tiRoomName":"Hall A"}
+					]`)
+				case "/api/v2/locations":
+					locationsListed++
+					w.WriteHeader(tt.status)
+					w.Write([]byte(tt.body))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			client, err := New(server.URL+"/api/v2", "testuser", "testpass")
+			if err != nil {
+				t.Fatalf("Failed to create client: %v", err)
+			}
+
+			tree, err := client.GetLocationTree(context.Background())
+			if err != nil {
+				t.Fatalf("GetLocationTree failed: %v", err)
+			}
+			if hall := tree.Find("Hall A"); hall == nil || hall.ItemCount != 2 || hall.Parent.Code != "RDU2" {
+				t.Errorf("Expected synthesized Hall A with 2 items, got %+v", hall)
+			}
+		})
+	}
+}
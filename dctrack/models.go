@@ -33,6 +33,7 @@ type DCTrackItem struct {
 	TiAssetTag       string  `json:"ti_asset_tag"`
 	TiFormFactor     string  `json:"ti_form_factor"`
 	TiMounting       string  `json:"ti_mounting"`
+	RailsUsed        string  `json:"rails_used"` // Front, Rear or Both
 	TiWidth          float64 `json:"ti_width"`
 	TiDepth          float64 `json:"ti_depth"`
 	TiWeight         float64 `json:"ti_weight"`
@@ -86,7 +87,8 @@ type DCTrackItem struct {
 	PurchaseDate        *time.Time `json:"purchase_date"`
 	TiPlannedDecommDate *time.Time `json:"ti_planned_decomm_date"`
 
-	// Custom Fields
+	// Custom fields of the default layout. CustomFields holds every custom
+	// field the tenant returns, these included.
 	TiCustomFieldPrimaryContact         *string    `json:"ti_custom_field_primary_contact"`
 	TiCustomFieldContactTeamName        *string    `json:"ti_custom_field_contact_team_name"`
 	TiCustomFieldAuditRemarks           *string    `json:"ti_custom_field_audit_remarks"`
@@ -96,6 +98,11 @@ type DCTrackItem struct {
 	TiCustomFieldAssetStatus            *string    `json:"ti_custom_field_asset_status"`
 	TiCustomFieldPntIt                  *string    `json:"ti_custom_field_pnt_it"`
 
+	// Every tiCustomField_* column returned, keyed by field name without
+	// the prefix, e.g. "Audit Date". Being a map, it makes DCTrackItem
+	// incomparable with ==.
+	CustomFields map[string]CustomFieldValue `json:"custom_fields,omitempty"`
+
 	// Administrative Data
 	CmbSystemAdminTeam string `json:"cmb_system_admin_team"`
 	CmbSystemAdmin     string `json:"cmb_system_admin"`
@@ -107,6 +114,9 @@ type DCTrackItem struct {
 	LastUpdatedAt time.Time `json:"last_updated_at"`
 	CreatedAt     time.Time `json:"created_at"`
 	UpdatedAt     time.Time `json:"updated_at"`
+
+	// Model library spec, set by EnrichItems
+	ModelSpec *ModelDefinition `json:"model_spec,omitempty"`
 }
 
 // PowerSummary represents power consumption analytics from DCTrack
@@ -134,18 +144,23 @@ type AnalyticsSummary struct {
 
 // RefreshPlan represents asset refresh planning data
 type RefreshPlan struct {
-	TotalAssetsTracked int               `json:"total_assets_tracked"`
+	TotalAssetsTracked int               `json:"total_assets_tracked"` // Assets with a refresh date
+	Unscheduled        int               `json:"unscheduled"`          // Assets without one
 	ByContractEndYear  map[string]int    `json:"by_contract_end_year"`
 	ByInstallYear      map[string]int    `json:"by_install_year"`
+	ByDecommYear       map[string]int    `json:"by_decomm_year"`
+	ByWarrantyEndYear  map[string]int    `json:"by_warranty_end_year"`
 	UpcomingRefreshes  []UpcomingRefresh `json:"upcoming_refreshes"`
 }
 
 // UpcomingRefresh represents assets due for refresh
 type UpcomingRefresh struct {
-	Year       string   `json:"year"`
-	AssetCount int      `json:"asset_count"`
-	TotalValue float64  `json:"total_value"`
-	Locations  []string `json:"locations"`
+	Year          string   `json:"year"`
+	AssetCount    int      `json:"asset_count"`
+	OverdueCount  int      `json:"overdue_count"`  // Assets that were due before the plan's first year
+	TotalValue    float64  `json:"total_value"`    // Sum of TiPurchasePrice
+	ContractValue float64  `json:"contract_value"` // Sum of TiContractAmount
+	Locations     []string `json:"locations"`
 }
 
 // ContactAudit represents contact audit results
@@ -163,6 +178,46 @@ type VendorDistribution struct {
 	Percentage float64 `json:"percentage"`
 }
 
+// Cabinet represents a DCTrack cabinet (rack)
+type Cabinet struct {
+	ID            string  `json:"id"`
+	Name          string  `json:"name"`
+	Location      string  `json:"location"`
+	Status        string  `json:"status"`
+	Make          string  `json:"make"`
+	Model         string  `json:"model"`
+	RUHeight      int     `json:"ru_height"`
+	Row           string  `json:"row"`
+	RowPosition   string  `json:"row_position"`
+	Room          string  `json:"room"`
+	Floor         string  `json:"floor"`
+	PowerCapacity float64 `json:"power_capacity"`
+	Width         float64 `json:"width"`
+	Depth         float64 `json:"depth"`
+}
+
+// Make is a manufacturer in the DCTrack models library
+type Make struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// ModelDefinition is a model's spec from the DCTrack models library
+type ModelDefinition struct {
+	ID             string  `json:"id"`
+	Make           string  `json:"make"`
+	Model          string  `json:"model"`
+	ItemClass      string  `json:"item_class"`
+	Mounting       string  `json:"mounting"`
+	RUHeight       int     `json:"ru_height"`
+	NameplateWatts float64 `json:"nameplate_watts"`
+	Weight         float64 `json:"weight"`
+	Width          float64 `json:"width"`
+	Depth          float64 `json:"depth"`
+	DataPorts      int     `json:"data_ports"`
+	PowerPorts     int     `json:"power_ports"`
+}
+
 // CabinetUtilization represents cabinet space utilization
 type CabinetUtilization struct {
 	Location           string  `json:"location"`
@@ -13,6 +13,9 @@ type ItemsParams struct {
 	Make       string `url:"make,omitempty"`       // Filter by manufacturer
 	Model      string `url:"model,omitempty"`      // Filter by model
 	SearchText string `url:"searchText,omitempty"` // General text search across fields
+
+	// Column selection
+	Columns []string `url:"-"` // Columns to request instead of the standard set (id is always included)
 }
 
 // SearchParams represents parameters for DCTrack /quicksearch API
@@ -87,6 +90,13 @@ func (f *FilterBuilder) WithPagination(page, size int) *FilterBuilder {
 	return f
 }
 
+// WithColumns requests only the given columns, which must be known to the
+// client or be custom field columns
+func (f *FilterBuilder) WithColumns(columns ...string) *FilterBuilder {
+	f.params.Columns = append(f.params.Columns, columns...)
+	return f
+}
+
 // Build returns the constructed parameters
 func (f *FilterBuilder) Build() ItemsParams {
 	return f.params
@@ -0,0 +1,239 @@
+package dctrack
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+type columnFilter struct {
+	Name   string            `json:"name"`
+	Filter map[string]string `json:"filter"`
+}
+
+func TestFiltersReachTheWire(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  ItemsParams
+		filters []columnFilter
+		query   map[string]string
+	}{
+		{
+			name:   "no filters",
+			params: ItemsParams{},
+		},
+		{
+			name:   "vendor",
+			params: ByVendor("Dell"),
+			filters: []columnFilter{
+				{"cmbStatus", map[string]string{"eq": "Installed"}},
+				{"cmbMake", map[string]string{"eq": "Dell"}},
+			},
+		},
+		{
+			name:    "class and model",
+			params:  NewFilterBuilder().ItemClass("Network").Model("Nexus 9336C").Build(),
+			filters: []columnFilter{{"tiClass", map[string]string{"eq": "Network"}}, {"cmbModel", map[string]string{"eq": "Nexus 9336C"}}},
+		},
+		{
+			// Location stays a partial match on the location parameter
+			name:    "location with spaces and status",
+			params:  NewFilterBuilder().Location("SITE A/B & C").Status("Installed").Build(),
+			filters: []columnFilter{{"cmbStatus", map[string]string{"eq": "Installed"}}},
+			query:   map[string]string{"location": "SITE A/B & C"},
+		},
+		{
+			name:   "every filter with quotes",
+			params: ItemsParams{Location: "RDU2", Status: "Planned", ItemClass: "Device", Make: `Dell "EMC"`, Model: `R750\xd`, SearchText: "web #1"},
+			filters: []columnFilter{
+				{"cmbStatus", map[string]string{"eq": "Planned"}},
+				{"tiClass", map[string]string{"eq": "Device"}},
+				{"cmbMake", map[string]string{"eq": `Dell "EMC"`}},
+				{"cmbModel", map[string]string{"eq": `R750\xd`}},
+			},
+			query: map[string]string{"location": "RDU2", "searchText": "web #1"},
+		},
+		{
+			name:    "filters with explicit columns",
+			params:  NewFilterBuilder().Make("HPE").WithColumns("tiName").Build(),
+			filters: []columnFilter{{"cmbMake", map[string]string{"eq": "HPE"}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				filters []columnFilter
+				query   map[string][]string
+			)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/api/v2/authentication/login":
+					w.Header().Set("Authorization", "Bearer mock-token-12345")
+				case "/api/v2/quicksearch/items":
+					body, _ := io.ReadAll(r.Body)
+					var payload struct {
+						Columns []columnFilter `json:"columns"`
+					}
+					if err := json.Unmarshal(body, &payload); err != nil {
+						t.Errorf("Expected a JSON body, got %s: %v", body, err)
+					}
+					filters, query = payload.Columns, r.URL.Query()
+					w.Write([]byte(`{"searchResults":{"items":[]}}`))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			client, err := New(server.URL+"/api/v2", "testuser", "testpass")
+			if err != nil {
+				t.Fatalf("Failed to create client: %v", err)
+			}
+			if _, err := client.GetItemsWithParams(context.Background(), tt.params); err != nil {
+				t.Fatalf("GetItemsWithParams failed: %v", err)
+			}
+
+			if !reflect.DeepEqual(filters, tt.filters) {
+				t.Errorf("Expected column filters %v, got %v", tt.filters, filters)
+			}
+			for key, expected := range tt.query {
+				if got := query[key]; len(got) != 1 || got[0] != expected {
+					t.Errorf("Expected %s=%q, got %q", key, expected, got)
+				}
+			}
+			if got, ok := query["status"]; ok {
+				t.Errorf("Expected status only as a column filter, got status=%q", got)
+			}
+			if got := query["pageNumber"]; len(got) != 1 || got[0] != "1" {
+				t.Errorf("Expected pageNumber=1, got %q", got)
+			}
+		})
+	}
+}
+
+func TestFiltersIgnoredByServer(t *testing.T) {
+	var selected []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/authentication/login":
+			w.Header().Set("Authorization", "Bearer mock-token-12345")
+		case "/api/v2/quicksearch/items":
+			var payload struct {
+				SelectedColumns []map[string]string `json:"selectedColumns"`
+			}
+			json.NewDecoder(r.Body).Decode(&payload)
+			selected = nil
+			for _, column := range payload.SelectedColumns {
+				selected = append(selected, column["name"])
+			}
+			if r.URL.Query().Get("pageNumber") != "1" {
+				w.Write([]byte(`{"searchResults":{"items":[]}}`))
+				return
+			}
+
+			// Column filters are ignored and every row is returned
+			w.Write([]byte(`{"searchResults":{"items":[
+				{"id":"1","tiName":"web-01","cmbMake":"Dell","cmbStatus":"Installed"},
+				{"id":"2","tiName":"web-02","cmbMake":"HPE","cmbStatus":"Installed"},
+				{"id":"3","tiName":"web-03","cmbMake":"Dell","cmbStatus":"Planned"}
+			]}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(server.URL+"/api/v2", "testuser", "testpass")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	items, err := client.GetItemsWithParams(context.Background(), NewFilterBuilder().Make("Dell").Status("Installed").WithColumns("tiName").Build())
+	if err != nil {
+		t.Fatalf("GetItemsWithParams failed: %v", err)
+	}
+	if len(items) != 1 || items[0].ID != "1" {
+		t.Errorf("Expected only the installed Dell item, got %+v", items)
+	}
+	for _, column := range []string{"cmbMake", "cmbStatus"} {
+		found := false
+		for _, name := range selected {
+			found = found || name == column
+		}
+		if !found {
+			t.Errorf("Expected filtered column %s to be selected, got %v", column, selected)
+		}
+	}
+}
+
+func TestLocationFilterIsConsistent(t *testing.T) {
+	var (
+		filters []columnFilter
+		query   map[string][]string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/authentication/login":
+			w.Header().Set("Authorization", "Bearer mock-token-12345")
+		case "/api/v2/quicksearch/items":
+			var payload struct {
+				Columns []columnFilter `json:"columns"`
+			}
+			json.NewDecoder(r.Body).Decode(&payload)
+			filters, query = payload.Columns, r.URL.Query()
+			w.Write([]byte(`{"searchResults":{"items":[]}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(server.URL+"/api/v2", "testuser", "testpass")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx := context.Background()
+
+	calls := map[string]func() error{
+		"GetItemsWithParams": func() error {
+			_, err := client.GetItemsWithParams(ctx, ItemsParams{Location: "RDU"})
+			return err
+		},
+		"GetItemsWhere": func() error {
+			_, err := client.GetItemsWhere(ctx, ItemsParams{Location: "RDU"}, Eq("make", "Dell"))
+			return err
+		},
+		"ListCabinets": func() error {
+			_, err := client.ListCabinets(ctx, "RDU")
+			return err
+		},
+		"GetCabinetItems": func() error {
+			_, err := client.GetCabinetItems(ctx, Cabinet{Name: "CAB-A1", Location: "RDU"})
+			return err
+		},
+		"GetPowerItems": func() error {
+			_, err := client.GetPowerItems(ctx, PowerParams{Location: "RDU"})
+			return err
+		},
+	}
+
+	for name, call := range calls {
+		filters, query = nil, nil
+		if err := call(); err != nil {
+			t.Fatalf("%s failed: %v", name, err)
+		}
+		if got := query["location"]; len(got) != 1 || got[0] != "RDU" {
+			t.Errorf("%s: Expected location=RDU, got %q", name, got)
+		}
+		for _, filter := range filters {
+			if filter.Name == "cmbLocation" {
+				t.Errorf("%s: Expected no exact cmbLocation filter, got %v", name, filters)
+			}
+		}
+	}
+}
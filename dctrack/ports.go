@@ -0,0 +1,412 @@
+package dctrack
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// maxTraceHops bounds a trace on miscabled data where a loop is not caught
+// by port identity alone
+const maxTraceHops = 64
+
+// PortKind distinguishes data ports from power ports
+type PortKind string
+
+const (
+	PortData  PortKind = "data"
+	PortPower PortKind = "power"
+)
+
+// Port is a data or power port on an item, with the far end of its
+// connection when it is cabled
+type Port struct {
+	ID       string   `json:"id"`
+	Kind     PortKind `json:"kind"`
+	ItemID   string   `json:"item_id"`
+	ItemName string   `json:"item_name"`
+	Name     string   `json:"name"`
+
+	// Face and PairedPortID describe the pass-through of patch panel ports:
+	// a front port is wired to the rear port of the same name, or to the
+	// port DCTrack names as its pair
+	Face         string `json:"face,omitempty"`
+	PairedPortID string `json:"paired_port_id,omitempty"`
+
+	Connector  string `json:"connector,omitempty"`
+	Media      string `json:"media,omitempty"`
+	Speed      string `json:"speed,omitempty"`
+	VLAN       string `json:"vlan,omitempty"`
+	MACAddress string `json:"mac_address,omitempty"`
+
+	// Power ports only. Subclass tells inputs ("Input Cord") from outlets
+	// and breakers; RatedWatts is derived from the amps, volts and phase
+	// when DCTrack does not report it.
+	Subclass      string  `json:"subclass,omitempty"`
+	Voltage       string  `json:"voltage,omitempty"`
+	Phase         string  `json:"phase,omitempty"`
+	AmpsRated     float64 `json:"amps_rated,omitempty"`
+	RatedWatts    float64 `json:"rated_watts,omitempty"`
+	BudgetedWatts float64 `json:"budgeted_watts,omitempty"`
+	MeasuredWatts float64 `json:"measured_watts,omitempty"`
+
+	ConnectedItemID   string `json:"connected_item_id,omitempty"`
+	ConnectedItemName string `json:"connected_item_name,omitempty"`
+	ConnectedPortID   string `json:"connected_port_id,omitempty"`
+	ConnectedPortName string `json:"connected_port_name,omitempty"`
+	ConnectionID      string `json:"connection_id,omitempty"`
+}
+
+// Connected reports whether the port is cabled to another port
+func (p Port) Connected() bool {
+	return p.ConnectedItemID != "" || p.ConnectedItemName != ""
+}
+
+// PortRef identifies one end of a connection
+type PortRef struct {
+	ItemID   string `json:"item_id"`
+	ItemName string `json:"item_name"`
+	PortID   string `json:"port_id"`
+	PortName string `json:"port_name"`
+}
+
+// Connection is a DCTrack data or power connection record between two ports
+type Connection struct {
+	ID          string   `json:"id"`
+	Kind        PortKind `json:"kind"`
+	Status      string   `json:"status"`
+	CableLabel  string   `json:"cable_label,omitempty"`
+	Source      PortRef  `json:"source"`
+	Destination PortRef  `json:"destination"`
+}
+
+// TraceHop is one cable of a traced circuit
+type TraceHop struct {
+	From         Port   `json:"from"`
+	To           Port   `json:"to"`
+	ConnectionID string `json:"connection_id,omitempty"`
+}
+
+// PortTrace follows a data connection from a port to its far end
+type PortTrace struct {
+	Start Port       `json:"start"`
+	Hops  []TraceHop `json:"hops"`
+
+	// Complete is true when the trace reached a port that is not a patch
+	// panel pass-through. Otherwise Problem says where and why it stopped.
+	Complete bool   `json:"complete"`
+	Problem  string `json:"problem,omitempty"`
+}
+
+// End returns the last port reached, which is the start port when it is
+// not connected
+func (t *PortTrace) End() Port {
+	if len(t.Hops) == 0 {
+		return t.Start
+	}
+	return t.Hops[len(t.Hops)-1].To
+}
+
+// Path renders the trace as "web-01 eth0 -> PP-A1 1 | 1 -> sw-01 Gi1/0/1",
+// where "|" marks a pass-through to the other face of a patch panel
+func (t *PortTrace) Path() string {
+	var b strings.Builder
+	b.WriteString(portLabel(t.Start))
+	for i, hop := range t.Hops {
+		if i > 0 {
+			fmt.Fprintf(&b, " | %s", hop.From.Name)
+		}
+		fmt.Fprintf(&b, " -> %s", portLabel(hop.To))
+	}
+	return b.String()
+}
+
+// GetDataPorts retrieves the data ports of an item
+func (c *Client) GetDataPorts(ctx context.Context, itemID string) ([]Port, error) {
+	return c.getPorts(ctx, itemID, PortData)
+}
+
+// GetPowerPorts retrieves the power ports of an item
+func (c *Client) GetPowerPorts(ctx context.Context, itemID string) ([]Port, error) {
+	return c.getPorts(ctx, itemID, PortPower)
+}
+
+// GetConnections retrieves the connection records of kind that have an
+// end on the item
+func (c *Client) GetConnections(ctx context.Context, itemID string, kind PortKind) ([]Connection, error) {
+	if itemID == "" {
+		return nil, fmt.Errorf("error getting connections: empty item ID")
+	}
+
+	connectionsURL := fmt.Sprintf("%s/connections/%sconnections?itemId=%s",
+		c.config.URL, kind, url.QueryEscape(itemID))
+	records, err := c.getRecordList(ctx, "connections", connectionsURL,
+		string(kind)+"Connections", "connections")
+	if err != nil {
+		return nil, fmt.Errorf("error getting %s connections of item %s: %w", kind, itemID, err)
+	}
+
+	connections := make([]Connection, 0, len(records))
+	for _, record := range records {
+		connections = append(connections, connectionFromRecord(record, kind))
+	}
+	return connections, nil
+}
+
+// TraceDataPort follows the cabling from an item's data port, named by port
+// name or ID, through any patch panels to the far end. A broken chain is
+// reported in the trace's Problem rather than as an error; errors are
+// reserved for failed lookups.
+func (c *Client) TraceDataPort(ctx context.Context, itemID, port string) (*PortTrace, error) {
+	cache := map[string][]Port{}
+	portsOf := func(id string) ([]Port, error) {
+		if ports, ok := cache[id]; ok {
+			return ports, nil
+		}
+		ports, err := c.GetDataPorts(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		cache[id] = ports
+		return ports, nil
+	}
+
+	ports, err := portsOf(itemID)
+	if err != nil {
+		return nil, err
+	}
+	start, ok := findPort(ports, port, port)
+	if !ok {
+		return nil, fmt.Errorf("item %s has no data port %s: %w", itemID, port, ErrNotFound)
+	}
+
+	trace := &PortTrace{Start: start}
+	visited := map[string]bool{portKey(start): true}
+	current := start
+	for len(trace.Hops) < maxTraceHops {
+		if !current.Connected() {
+			if len(trace.Hops) == 0 {
+				trace.Problem = fmt.Sprintf("%s is not connected", portLabel(current))
+			} else {
+				trace.Problem = fmt.Sprintf("patch panel port %s is not connected", portLabel(current))
+			}
+			return trace, nil
+		}
+		if current.ConnectedItemID == "" {
+			trace.Problem = fmt.Sprintf("%s is connected to %s, which has no item ID",
+				portLabel(current), current.ConnectedItemName)
+			return trace, nil
+		}
+
+		farPorts, err := portsOf(current.ConnectedItemID)
+		if err != nil {
+			return nil, fmt.Errorf("error tracing %s: %w", portLabel(current), err)
+		}
+		far, ok := findPort(farPorts, current.ConnectedPortID, current.ConnectedPortName)
+		if !ok {
+			farName := current.ConnectedPortName
+			if farName == "" {
+				farName = current.ConnectedPortID
+			}
+			trace.Problem = fmt.Sprintf("%s is connected to port %s of item %s, which does not exist",
+				portLabel(current), farName, current.ConnectedItemID)
+			return trace, nil
+		}
+		trace.Hops = append(trace.Hops, TraceHop{From: current, To: far, ConnectionID: current.ConnectionID})
+
+		if !isPanelPort(far) {
+			trace.Complete = true
+			return trace, nil
+		}
+		next, ok := passThrough(farPorts, far)
+		if !ok {
+			trace.Problem = fmt.Sprintf("patch panel port %s has no port on the other face", portLabel(far))
+			return trace, nil
+		}
+		if visited[portKey(far)] || visited[portKey(next)] {
+			trace.Problem = fmt.Sprintf("cabling loops back to %s", portLabel(far))
+			return trace, nil
+		}
+		visited[portKey(far)] = true
+		visited[portKey(next)] = true
+		current = next
+	}
+
+	trace.Problem = fmt.Sprintf("trace stopped after %d hops", maxTraceHops)
+	return trace, nil
+}
+
+// getPorts fetches and maps the ports of kind on an item
+func (c *Client) getPorts(ctx context.Context, itemID string, kind PortKind) ([]Port, error) {
+	if itemID == "" {
+		return nil, fmt.Errorf("error getting %s ports: empty item ID", kind)
+	}
+
+	portsURL := fmt.Sprintf("%s/items/%s/%sports", c.config.URL, url.PathEscape(itemID), kind)
+	records, err := c.getRecordList(ctx, string(kind)+"ports", portsURL, string(kind)+"Ports", "ports")
+	if err != nil {
+		return nil, fmt.Errorf("error getting %s ports of item %s: %w", kind, itemID, err)
+	}
+
+	ports := make([]Port, 0, len(records))
+	for _, record := range records {
+		port := portFromRecord(record, kind)
+		if port.ItemID == "" {
+			port.ItemID = itemID
+		}
+		ports = append(ports, port)
+	}
+
+	c.logger.Debug("Fetched ports from DCTrack",
+		zap.String("item_id", itemID),
+		zap.String("kind", string(kind)),
+		zap.Int("count", len(ports)))
+	return ports, nil
+}
+
+// getRecordList GETs a list endpoint and decodes its records
+func (c *Client) getRecordList(ctx context.Context, operation, endpoint string, members ...string) ([]map[string]interface{}, error) {
+	resp, err := c.doAuthorized(ctx, operation, "GET", endpoint, true, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response body: %w", err)
+	}
+	return decodeRecordList(bodyBytes, members...)
+}
+
+// findPort finds a port by ID, then by case-insensitive name
+func findPort(ports []Port, id, name string) (Port, bool) {
+	if id != "" {
+		for _, port := range ports {
+			if port.ID == id {
+				return port, true
+			}
+		}
+	}
+	if name != "" {
+		for _, port := range ports {
+			if strings.EqualFold(port.Name, name) {
+				return port, true
+			}
+		}
+	}
+	return Port{}, false
+}
+
+// isPanelPort reports patch panel ports, which have a face or a pair.
+// Other ports are end points.
+func isPanelPort(port Port) bool {
+	return port.Face != "" || port.PairedPortID != ""
+}
+
+// passThrough returns the port on the other face of a patch panel that
+// port is wired to
+func passThrough(ports []Port, port Port) (Port, bool) {
+	if port.PairedPortID != "" {
+		for _, other := range ports {
+			if other.ID == port.PairedPortID {
+				return other, true
+			}
+		}
+		return Port{}, false
+	}
+	for _, other := range ports {
+		if other.Face != "" && !strings.EqualFold(other.Face, port.Face) && strings.EqualFold(other.Name, port.Name) {
+			return other, true
+		}
+	}
+	return Port{}, false
+}
+
+// portKey identifies a port across items
+func portKey(port Port) string {
+	return port.ItemID + "/" + port.ID + "/" + port.Name + "/" + port.Face
+}
+
+// portLabel names a port as "item port", adding the face on panels
+func portLabel(port Port) string {
+	item := port.ItemName
+	if item == "" {
+		item = port.ItemID
+	}
+	label := item + " " + port.Name
+	if port.Face != "" {
+		label += " (" + strings.ToLower(port.Face) + ")"
+	}
+	return label
+}
+
+// portFromRecord maps a port record
+func portFromRecord(record map[string]interface{}, kind PortKind) Port {
+	get := func(key string) string {
+		return recordString(record, key)
+	}
+
+	port := Port{
+		ID:           get("portId"),
+		Kind:         kind,
+		ItemID:       get("itemId"),
+		ItemName:     get("itemName"),
+		Name:         get("portName"),
+		Face:         get("face"),
+		PairedPortID: get("pairedPortId"),
+		Connector:    get("connector"),
+		Media:        get("media"),
+		Speed:        get("speed"),
+		VLAN:         get("vlan"),
+		MACAddress:   get("macAddress"),
+		Voltage:      get("volts"),
+		Phase:        get("phase"),
+		Subclass:     get("portSubclass"),
+		AmpsRated:    recordFloat(record, "ampsRated"),
+
+		RatedWatts:    recordFloat(record, "ratedWatts"),
+		BudgetedWatts: recordFloat(record, "budgetedWatts"),
+		MeasuredWatts: recordFloat(record, "measuredWatts"),
+
+		ConnectedItemID:   get("connectedItemId"),
+		ConnectedItemName: get("connectedItemName"),
+		ConnectedPortID:   get("connectedPortId"),
+		ConnectedPortName: get("connectedPortName"),
+		ConnectionID:      get("connectionId"),
+	}
+	if port.RatedWatts == 0 {
+		port.RatedWatts = ratedWatts(port.AmpsRated, port.Voltage, port.Phase)
+	}
+	return port
+}
+
+// connectionFromRecord maps a connection record
+func connectionFromRecord(record map[string]interface{}, kind PortKind) Connection {
+	get := func(key string) string {
+		return recordString(record, key)
+	}
+
+	return Connection{
+		ID:         get("connectionId"),
+		Kind:       kind,
+		Status:     get("status"),
+		CableLabel: get("cableLabel"),
+		Source: PortRef{
+			ItemID:   get("sourceItemId"),
+			ItemName: get("sourceItemName"),
+			PortID:   get("sourcePortId"),
+			PortName: get("sourcePortName"),
+		},
+		Destination: PortRef{
+			ItemID:   get("destinationItemId"),
+			ItemName: get("destinationItemName"),
+			PortID:   get("destinationPortId"),
+			PortName: get("destinationPortName"),
+		},
+	}
+}
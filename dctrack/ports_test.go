@@ -0,0 +1,211 @@
+package dctrack
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newPortServer serves data ports from a map of item ID to response body
+func newPortServer(t *testing.T, ports map[string]string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v2/authentication/login" {
+			w.Header().Set("Authorization", "Bearer mock-token-12345")
+			return
+		}
+
+		id, ok := strings.CutPrefix(r.URL.Path, "/api/v2/items/")
+		id, found := strings.CutSuffix(id, "/dataports")
+		if body, exists := ports[id]; ok && found && exists {
+			w.Write([]byte(body))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+}
+
+func TestTraceDataPort(t *testing.T) {
+	// web-01 eth0 -> PP-A1 front 1, rear 1 -> PP-B1 rear 1, front 1 -> sw-01 Gi1/0/1
+	cabled := map[string]string{
+		"10": `[
+			{"portId":100,"portName":"eth0","itemName":"web-01","connectedItemId":20,"connectedPortId":200,"connectionId":"c1"},
+			{"portId":101,"portName":"eth1","itemName":"web-01"}
+		]`,
+		"20": `{"dataPorts":[
+			{"portId":200,"portName":"1","face":"Front","itemName":"PP-A1","connectedItemId":10,"connectedPortId":100},
+			{"portId":201,"portName":"1","face":"Rear","itemName":"PP-A1","connectedItemId":30,"connectedPortName":"1","connectionId":"c2"},
+			{"portId":202,"portName":"2","face":"Rear","itemName":"PP-A1","connectedItemId":30,"connectedPortId":301}
+		]}`,
+		"30": `[
+			{"portId":300,"portName":"1","face":"Rear","itemName":"PP-B1","pairedPortId":310,"connectedItemId":20,"connectedPortId":201},
+			{"portId":310,"portName":"1","face":"Front","itemName":"PP-B1","connectedItemId":40,"connectedPortName":"gi1/0/1","connectionId":"c3"},
+			{"portId":301,"portName":"2","face":"Rear","itemName":"PP-B1"},
+			{"portId":311,"portName":"2","face":"Front","itemName":"PP-B1"}
+		]`,
+		"40": `[{"portId":400,"portName":"Gi1/0/1","itemName":"sw-01","connectedItemId":30,"connectedPortId":310}]`,
+	}
+
+	tests := []struct {
+		name     string
+		ports    map[string]string
+		itemID   string
+		port     string
+		hops     int
+		complete bool
+		problem  string
+		path     string
+	}{
+		{
+			name: "through two panels", ports: cabled, itemID: "10", port: "eth0",
+			hops: 3, complete: true,
+			path: "web-01 eth0 -> PP-A1 1 (front) | 1 -> PP-B1 1 (rear) | 1 -> sw-01 Gi1/0/1",
+		},
+		{
+			name: "from the far end", ports: cabled, itemID: "40", port: "400",
+			hops: 3, complete: true,
+			path: "sw-01 Gi1/0/1 -> PP-B1 1 (front) | 1 -> PP-A1 1 (rear) | 1 -> web-01 eth0",
+		},
+		{
+			name: "not connected", ports: cabled, itemID: "10", port: "eth1",
+			problem: "web-01 eth1 is not connected",
+		},
+		{
+			name: "dead end at a panel", ports: cabled, itemID: "20", port: "202",
+			hops: 1, problem: "patch panel port PP-B1 2 (front) is not connected",
+		},
+		{
+			name: "missing far port", itemID: "10", port: "eth0",
+			ports: map[string]string{
+				"10": cabled["10"],
+				"20": `[]`,
+			},
+			problem: "web-01 eth0 is connected to port 200 of item 20, which does not exist",
+		},
+		{
+			name: "panel port without a pair", itemID: "10", port: "eth0",
+			ports: map[string]string{
+				"10": cabled["10"],
+				"20": `[{"portId":200,"portName":"1","face":"Front","itemName":"PP-A1"}]`,
+			},
+			hops: 1, problem: "patch panel port PP-A1 1 (front) has no port on the other face",
+		},
+		{
+			name: "loop", itemID: "10", port: "eth0",
+			ports: map[string]string{
+				"10": cabled["10"],
+				"20": `[
+					{"portId":200,"portName":"1","face":"Front","itemName":"PP-A1"},
+					{"portId":201,"portName":"1","face":"Rear","itemName":"PP-A1","connectedItemId":20,"connectedPortId":200}
+				]`,
+			},
+			hops: 2, problem: "cabling loops back to PP-A1 1 (front)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newPortServer(t, tt.ports)
+			defer server.Close()
+
+			client, err := New(server.URL+"/api/v2", "testuser", "testpass")
+			if err != nil {
+				t.Fatalf("Failed to create client: %v", err)
+			}
+
+			trace, err := client.TraceDataPort(context.Background(), tt.itemID, tt.port)
+			if err != nil {
+				t.Fatalf("TraceDataPort failed: %v", err)
+			}
+			if len(trace.Hops) != tt.hops || trace.Complete != tt.complete || trace.Problem != tt.problem {
+				t.Errorf("Expected %d hops, complete %v, problem %q; got %d, %v, %q",
+					tt.hops, tt.complete, tt.problem, len(trace.Hops), trace.Complete, trace.Problem)
+			}
+			if tt.path != "" && trace.Path() != tt.path {
+				t.Errorf("Expected path %q, got %q", tt.path, trace.Path())
+			}
+		})
+	}
+}
+
+func TestTraceDataPortErrors(t *testing.T) {
+	server := newPortServer(t, map[string]string{
+		"10": `[{"portId":100,"portName":"eth0","connectedItemId":99,"connectedPortName":"1"}]`,
+	})
+	defer server.Close()
+
+	client, err := New(server.URL+"/api/v2", "testuser", "testpass")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx := context.Background()
+
+	if _, err := client.TraceDataPort(ctx, "10", "eth9"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for an unknown port, got %v", err)
+	}
+	if _, err := client.TraceDataPort(ctx, "10", "eth0"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for an unknown far item, got %v", err)
+	}
+}
+
+func TestPortsAndConnections(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/authentication/login":
+			w.Header().Set("Authorization", "Bearer mock-token-12345")
+		case "/api/v2/items/10/powerports":
+			w.Write([]byte(`{"powerPorts":[
+				{"portId":1,"portName":"PS1","volts":"208","phase":"Single","ampsRated":"10","connectedItemId":50,"connectedItemName":"PDU-A","connectedPortName":"AA1"},
+				{"portId":2,"portName":"PS2","volts":"208","phase":"Single","ampsRated":10}
+			]}`))
+		case "/api/v2/connections/dataconnections":
+			if r.URL.Query().Get("itemId") != "10" {
+				t.Errorf("Expected itemId 10, got %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"dataConnections":[
+				{"connectionId":7,"status":"Installed","sourceItemId":10,"sourceItemName":"web-01","sourcePortName":"eth0",
+				 "destinationItemId":20,"destinationItemName":"PP-A1","destinationPortName":"1"}
+			]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(server.URL+"/api/v2", "testuser", "testpass")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx := context.Background()
+
+	ports, err := client.GetPowerPorts(ctx, "10")
+	if err != nil {
+		t.Fatalf("GetPowerPorts failed: %v", err)
+	}
+	if len(ports) != 2 || ports[0].ItemID != "10" || ports[0].Kind != PortPower || ports[0].AmpsRated != 10 || ports[1].AmpsRated != 10 {
+		t.Errorf("Unexpected power ports %+v", ports)
+	}
+	if !ports[0].Connected() || ports[1].Connected() {
+		t.Errorf("Expected only PS1 connected, got %+v", ports)
+	}
+
+	connections, err := client.GetConnections(ctx, "10", PortData)
+	if err != nil {
+		t.Fatalf("GetConnections failed: %v", err)
+	}
+	expected := Connection{
+		ID: "7", Kind: PortData, Status: "Installed",
+		Source:      PortRef{ItemID: "10", ItemName: "web-01", PortName: "eth0"},
+		Destination: PortRef{ItemID: "20", ItemName: "PP-A1", PortName: "1"},
+	}
+	if len(connections) != 1 || connections[0] != expected {
+		t.Errorf("Expected %+v, got %+v", expected, connections)
+	}
+
+	if _, err := client.GetDataPorts(ctx, "10"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for missing data ports, got %v", err)
+	}
+}
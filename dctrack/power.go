@@ -0,0 +1,420 @@
+package dctrack
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// Power types accepted in PowerParams.PowerType
+const (
+	PowerOriginal    = "original"    // tiItemOriginalPower, the default
+	PowerConsumption = "consumption" // tiEffectivePower
+	PowerPotential   = "potential"   // tiPotentialPower
+)
+
+// powerColumns maps each power type PowerOf accepts to the column holding it
+var powerColumns = map[string]string{
+	"":               "tiItemOriginalPower",
+	PowerOriginal:    "tiItemOriginalPower",
+	PowerConsumption: "tiEffectivePower",
+	"effective":      "tiEffectivePower",
+	PowerPotential:   "tiPotentialPower",
+}
+
+// GetPowerItems retrieves the items with power data of params.PowerType
+// within the location, cabinet and power range of params. Items reporting
+// no power of that type are left out.
+func (c *Client) GetPowerItems(ctx context.Context, params PowerParams) ([]DCTrackItem, error) {
+	if _, err := PowerOf(DCTrackItem{}, params.PowerType); err != nil {
+		return nil, err
+	}
+	if params.MinPower < 0 || params.MaxPower < 0 || (params.MaxPower > 0 && params.MinPower > params.MaxPower) {
+		return nil, fmt.Errorf("invalid power range %g-%g", params.MinPower, params.MaxPower)
+	}
+
+	itemsParams := ItemsParams{Location: params.Location}
+	payload, err := c.itemsPayload(itemsParams)
+	if err != nil {
+		return nil, err
+	}
+	withColumn(payload, "cmbCabinet")
+	withColumn(payload, PowerColumn(params.PowerType))
+	if params.Cabinet != "" {
+		withColumnFilter(payload, "cmbCabinet", params.Cabinet)
+	}
+
+	items, err := c.collectItems(ctx, itemsParams, payload)
+	if err != nil {
+		return nil, fmt.Errorf("error getting power items: %w", err)
+	}
+
+	var powered []DCTrackItem
+	for _, item := range items {
+		if params.Cabinet != "" && item.Cabinet != params.Cabinet {
+			continue
+		}
+		power, _ := PowerOf(item, params.PowerType)
+		if power <= 0 || power < params.MinPower || (params.MaxPower > 0 && power > params.MaxPower) {
+			continue
+		}
+		powered = append(powered, item)
+	}
+
+	c.logger.Info("Fetched power items from DCTrack",
+		zap.String("location", params.Location),
+		zap.String("cabinet", params.Cabinet),
+		zap.Int("count", len(powered)))
+	return powered, nil
+}
+
+// PowerColumn returns the column holding the power type PowerOf reads, or
+// "" for an unknown type
+func PowerColumn(powerType string) string {
+	return powerColumns[strings.ToLower(powerType)]
+}
+
+// PowerOf returns an item's power in watts of the given type, one of
+// PowerOriginal (the default when empty), PowerConsumption or
+// PowerPotential. Consumption is read with EffectivePower.
+func PowerOf(item DCTrackItem, powerType string) (float64, error) {
+	switch strings.ToLower(powerType) {
+	case "", PowerOriginal:
+		return item.OriginalPower, nil
+	case PowerConsumption, "effective":
+		return EffectivePower(item), nil
+	case PowerPotential:
+		return item.TiPotentialPower, nil
+	default:
+		return 0, fmt.Errorf("unknown power type %q", powerType)
+	}
+}
+
+// PowerNodeKind is the role of a node in a power chain
+type PowerNodeKind string
+
+const (
+	PowerNodeOutlet   PowerNodeKind = "Outlet"
+	PowerNodeRackPDU  PowerNodeKind = "Rack PDU"
+	PowerNodeBreaker  PowerNodeKind = "Breaker"
+	PowerNodePanel    PowerNodeKind = "Panel"
+	PowerNodeFloorPDU PowerNodeKind = "Floor PDU"
+	PowerNodeUPS      PowerNodeKind = "UPS"
+	PowerNodeOther    PowerNodeKind = "Other"
+)
+
+// PowerLoad is the rated capacity of a node and the load budgeted and
+// measured against it, in watts. Zero means DCTrack does not record it.
+type PowerLoad struct {
+	RatedWatts    float64 `json:"rated_watts"`
+	BudgetedWatts float64 `json:"budgeted_watts"`
+	MeasuredWatts float64 `json:"measured_watts"`
+}
+
+// BudgetPercent returns the budgeted load as a percentage of the rating
+func (l PowerLoad) BudgetPercent() float64 {
+	if l.RatedWatts <= 0 {
+		return 0
+	}
+	return l.BudgetedWatts / l.RatedWatts * 100
+}
+
+// MeasuredPercent returns the measured load as a percentage of the rating
+func (l PowerLoad) MeasuredPercent() float64 {
+	if l.RatedWatts <= 0 {
+		return 0
+	}
+	return l.MeasuredWatts / l.RatedWatts * 100
+}
+
+// PowerNode is an outlet, breaker or power item in a power chain
+type PowerNode struct {
+	Kind     PowerNodeKind `json:"kind"`
+	ItemID   string        `json:"item_id"`
+	ItemName string        `json:"item_name"`
+	Port     string        `json:"port,omitempty"` // Outlet or breaker name
+	Load     PowerLoad     `json:"load"`
+}
+
+// PowerChain is the path power takes to an item or rack PDU, from the
+// outlet feeding it up to the UPS
+type PowerChain struct {
+	ItemID   string      `json:"item_id"`
+	ItemName string      `json:"item_name"`
+	Source   Port        `json:"source"` // The input the chain feeds
+	Nodes    []PowerNode `json:"nodes"`  // Nearest first
+
+	// Complete is true when the chain reached a UPS. Otherwise Problem says
+	// where and why it stopped.
+	Complete bool   `json:"complete"`
+	Problem  string `json:"problem,omitempty"`
+}
+
+// UPS returns the UPS at the top of the chain, or nil
+func (ch *PowerChain) UPS() *PowerNode {
+	if n := len(ch.Nodes); ch.Complete && n > 0 {
+		return &ch.Nodes[n-1]
+	}
+	return nil
+}
+
+// Path renders the chain as "PS1 -> PDU-A AA1 -> PDU-A -> PNL-1 CB1 -> PNL-1 -> UPS-A"
+func (ch *PowerChain) Path() string {
+	parts := []string{ch.Source.Name}
+	for _, node := range ch.Nodes {
+		label := node.ItemName
+		if node.Port != "" {
+			label += " " + node.Port
+		}
+		parts = append(parts, label)
+	}
+	return strings.Join(parts, " -> ")
+}
+
+// GetPowerChains traces the power chain from each power input of an item.
+// Dual-corded items return one chain per cord.
+func (c *Client) GetPowerChains(ctx context.Context, itemID string) ([]PowerChain, error) {
+	w := &powerWalker{client: c, items: map[string]*DCTrackItem{}, ports: map[string][]Port{}}
+
+	item, ports, err := w.load(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	// A device's power ports are all inputs; a PDU's chain starts at itself
+	kind := powerNodeKind(item.ItemClass)
+	var chains []PowerChain
+	for _, port := range ports {
+		if kind != PowerNodeOther && !isPowerInput(port) {
+			continue
+		}
+		chain := PowerChain{ItemID: item.ID, ItemName: item.Name, Source: port}
+		if kind != PowerNodeOther {
+			chain.Nodes = append(chain.Nodes, itemPowerNode(kind, *item, ports))
+		}
+		if err := w.walk(ctx, &chain, port); err != nil {
+			return nil, err
+		}
+		chains = append(chains, chain)
+	}
+	return chains, nil
+}
+
+// GetCabinetPowerChains traces the power chain of every rack PDU in a
+// cabinet
+func (c *Client) GetCabinetPowerChains(ctx context.Context, cabinetID string) ([]PowerChain, error) {
+	cabinet, err := c.GetCabinet(ctx, cabinetID)
+	if err != nil {
+		return nil, err
+	}
+	items, err := c.GetCabinetItems(ctx, *cabinet)
+	if err != nil {
+		return nil, err
+	}
+
+	var chains []PowerChain
+	for _, item := range items {
+		if powerNodeKind(item.ItemClass) != PowerNodeRackPDU {
+			continue
+		}
+		pduChains, err := c.GetPowerChains(ctx, item.ID)
+		if err != nil {
+			return nil, err
+		}
+		chains = append(chains, pduChains...)
+	}
+	return chains, nil
+}
+
+// powerWalker follows power connections upstream, caching the items and
+// ports it has fetched
+type powerWalker struct {
+	client *Client
+	items  map[string]*DCTrackItem
+	ports  map[string][]Port
+}
+
+// load fetches an item and its power ports
+func (w *powerWalker) load(ctx context.Context, id string) (*DCTrackItem, []Port, error) {
+	if item, ok := w.items[id]; ok {
+		return item, w.ports[id], nil
+	}
+
+	item, err := w.client.GetItemByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	ports, err := w.client.GetPowerPorts(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i := range ports {
+		if ports[i].ItemName == "" {
+			ports[i].ItemName = item.Name
+		}
+	}
+	w.items[id], w.ports[id] = item, ports
+	return item, ports, nil
+}
+
+// walk follows input upstream, appending a node for each outlet or breaker
+// and each item it passes, until it reaches a UPS or the chain breaks
+func (w *powerWalker) walk(ctx context.Context, chain *PowerChain, input Port) error {
+	visited := map[string]bool{input.ItemID: true}
+	current := input
+	for len(chain.Nodes) < maxTraceHops {
+		if !current.Connected() {
+			chain.Problem = fmt.Sprintf("%s is not connected", portLabel(current))
+			return nil
+		}
+		if current.ConnectedItemID == "" {
+			chain.Problem = fmt.Sprintf("%s is connected to %s, which has no item ID",
+				portLabel(current), current.ConnectedItemName)
+			return nil
+		}
+		if visited[current.ConnectedItemID] {
+			chain.Problem = fmt.Sprintf("power chain loops back to %s", current.ConnectedItemName)
+			return nil
+		}
+		visited[current.ConnectedItemID] = true
+
+		item, ports, err := w.load(ctx, current.ConnectedItemID)
+		if err != nil {
+			return fmt.Errorf("error tracing power from %s: %w", portLabel(current), err)
+		}
+
+		kind := powerNodeKind(item.ItemClass)
+		if feed, ok := findPort(ports, current.ConnectedPortID, current.ConnectedPortName); ok && kind != PowerNodeUPS {
+			chain.Nodes = append(chain.Nodes, PowerNode{
+				Kind:     feedKind(kind),
+				ItemID:   item.ID,
+				ItemName: item.Name,
+				Port:     feed.Name,
+				Load:     portLoad(feed),
+			})
+		}
+		chain.Nodes = append(chain.Nodes, itemPowerNode(kind, *item, ports))
+
+		if kind == PowerNodeUPS {
+			chain.Complete = true
+			return nil
+		}
+
+		next, ok := powerInput(ports)
+		if !ok {
+			chain.Problem = fmt.Sprintf("%s has no power input", item.Name)
+			return nil
+		}
+		current = next
+	}
+
+	chain.Problem = fmt.Sprintf("power chain stopped after %d nodes", maxTraceHops)
+	return nil
+}
+
+// powerNodeKind maps a DCTrack item class to its role in a power chain
+func powerNodeKind(itemClass string) PowerNodeKind {
+	class := strings.ToLower(itemClass)
+	switch {
+	case strings.Contains(class, "rack pdu"):
+		return PowerNodeRackPDU
+	case strings.Contains(class, "floor pdu"):
+		return PowerNodeFloorPDU
+	case strings.Contains(class, "panel"):
+		return PowerNodePanel
+	case strings.Contains(class, "ups"):
+		return PowerNodeUPS
+	default:
+		return PowerNodeOther
+	}
+}
+
+// feedKind names the port a downstream cord plugs into on an item of kind
+func feedKind(kind PowerNodeKind) PowerNodeKind {
+	if kind == PowerNodeRackPDU || kind == PowerNodeOther {
+		return PowerNodeOutlet
+	}
+	return PowerNodeBreaker
+}
+
+// itemPowerNode sums an item's load from its ports: the rating of its
+// inputs, the budgets of its outlets and breakers, and the measured load
+// of its inputs or, failing that, of its outlets
+func itemPowerNode(kind PowerNodeKind, item DCTrackItem, ports []Port) PowerNode {
+	node := PowerNode{Kind: kind, ItemID: item.ID, ItemName: item.Name}
+	var outputMeasured float64
+	for _, port := range ports {
+		if isPowerInput(port) {
+			node.Load.RatedWatts += port.RatedWatts
+			node.Load.MeasuredWatts += port.MeasuredWatts
+			continue
+		}
+		node.Load.BudgetedWatts += port.BudgetedWatts
+		outputMeasured += port.MeasuredWatts
+	}
+	if node.Load.MeasuredWatts == 0 {
+		node.Load.MeasuredWatts = outputMeasured
+	}
+	return node
+}
+
+// portLoad reads the load of a single outlet or breaker
+func portLoad(port Port) PowerLoad {
+	return PowerLoad{
+		RatedWatts:    port.RatedWatts,
+		BudgetedWatts: port.BudgetedWatts,
+		MeasuredWatts: port.MeasuredWatts,
+	}
+}
+
+// powerInput returns the first connected input of an item, or its first
+// input when none is connected
+func powerInput(ports []Port) (Port, bool) {
+	var first *Port
+	for i, port := range ports {
+		if !isPowerInput(port) {
+			continue
+		}
+		if port.Connected() {
+			return port, true
+		}
+		if first == nil {
+			first = &ports[i]
+		}
+	}
+	if first != nil {
+		return *first, true
+	}
+	return Port{}, false
+}
+
+// isPowerInput reports inputs by their port subclass, such as "Input Cord"
+// or "Inlet"
+func isPowerInput(port Port) bool {
+	subclass := strings.ToLower(port.Subclass)
+	return strings.Contains(subclass, "input") || strings.Contains(subclass, "inlet")
+}
+
+// ratedWatts derives a rating from amps, a voltage such as "208" or
+// "400/230V", and a phase such as "Single" or "3-Phase Wye"
+func ratedWatts(amps float64, voltage, phase string) float64 {
+	end := strings.IndexFunc(voltage, func(r rune) bool { return (r < '0' || r > '9') && r != '.' })
+	if end >= 0 {
+		voltage = voltage[:end]
+	}
+	volts, err := strconv.ParseFloat(voltage, 64)
+	if err != nil || amps <= 0 {
+		return 0
+	}
+
+	watts := amps * volts
+	phase = strings.ToLower(phase)
+	if strings.Contains(phase, "3") || strings.Contains(phase, "three") {
+		watts *= math.Sqrt(3)
+	}
+	return watts
+}
@@ -0,0 +1,236 @@
+package dctrack
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// powerItems are served as {id: [item record, power ports]}. srv-01 is fed
+// by PDU-A outlet AA1, PDU-A by breaker CB1 of PNL-1, and PNL-1 by UPS-A.
+// Both are mounted in cabinet CAB-A1.
+var powerItems = map[string][2]string{
+	"1": {`{"id":1,"tiName":"CAB-A1","tiClass":"Cabinet","cmbLocation":"RDU2"}`, `[]`},
+	"10": {
+		`{"id":10,"tiName":"srv-01","tiClass":"Device"}`,
+		`[
+			{"portId":1,"portName":"PS1","portSubclass":"Power Supply","connectedItemId":20,"connectedPortName":"AA1"},
+			{"portId":2,"portName":"PS2","portSubclass":"Power Supply"}
+		]`,
+	},
+	"20": {
+		`{"id":20,"tiName":"PDU-A","tiClass":"Rack PDU","cmbCabinet":"CAB-A1","cmbLocation":"RDU2"}`,
+		`[
+			{"portId":21,"portName":"Input","portSubclass":"Input Cord","volts":"208","phase":"Single","ampsRated":30,"measuredWatts":1800,"connectedItemId":30,"connectedPortId":31},
+			{"portId":22,"portName":"AA1","portSubclass":"Outlet","ampsRated":10,"volts":"208","budgetedWatts":500,"measuredWatts":350},
+			{"portId":23,"portName":"AA2","portSubclass":"Outlet","ampsRated":10,"volts":"208","budgetedWatts":700}
+		]`,
+	},
+	"30": {
+		`{"id":30,"tiName":"PNL-1","tiClass":"Power Panel"}`,
+		`[
+			{"portId":31,"portName":"CB1","portSubclass":"Breaker","ratedWatts":7200,"budgetedWatts":5000},
+			{"portId":32,"portName":"Main","portSubclass":"Input","connectedItemId":40,"connectedPortName":"OUT1"}
+		]`,
+	},
+	"40": {
+		`{"id":40,"tiName":"UPS-A","tiClass":"UPS"}`,
+		`[{"portId":41,"portName":"OUT1","portSubclass":"Output","budgetedWatts":90000}]`,
+	},
+}
+
+func newPowerServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v2/authentication/login" {
+			w.Header().Set("Authorization", "Bearer mock-token-12345")
+			return
+		}
+		if r.URL.Path == "/api/v2/quicksearch/items" {
+			if r.URL.Query().Get("pageNumber") != "1" {
+				w.Write([]byte(`{"searchResults":{"items":[]}}`))
+				return
+			}
+			w.Write([]byte(`{"searchResults":{"items":[
+				{"id":"20","tiName":"PDU-A","tiClass":"Rack PDU","cmbLocation":"RDU2","cmbCabinet":"CAB-A1"},
+				{"id":"10","tiName":"srv-01","tiClass":"Device","cmbLocation":"RDU2","cmbCabinet":"CAB-A1"}
+			]}}`))
+			return
+		}
+
+		id, ok := strings.CutPrefix(r.URL.Path, "/api/v2/items/")
+		id, ports := strings.CutSuffix(id, "/powerports")
+		record, exists := powerItems[id]
+		if !ok || !exists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if ports {
+			w.Write([]byte(record[1]))
+		} else {
+			w.Write([]byte(record[0]))
+		}
+	}))
+}
+
+func TestGetPowerChains(t *testing.T) {
+	server := newPowerServer(t)
+	defer server.Close()
+
+	client, err := New(server.URL+"/api/v2", "testuser", "testpass")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	chains, err := client.GetPowerChains(context.Background(), "10")
+	if err != nil {
+		t.Fatalf("GetPowerChains failed: %v", err)
+	}
+	if len(chains) != 2 {
+		t.Fatalf("Expected a chain per power supply, got %d", len(chains))
+	}
+
+	chain := chains[0]
+	if !chain.Complete || chain.UPS() == nil || chain.UPS().ItemName != "UPS-A" {
+		t.Errorf("Expected a complete chain to UPS-A, got %+v", chain)
+	}
+	if got := chain.Path(); got != "PS1 -> PDU-A AA1 -> PDU-A -> PNL-1 CB1 -> PNL-1 -> UPS-A" {
+		t.Errorf("Unexpected path %s", got)
+	}
+
+	tests := []struct {
+		kind PowerNodeKind
+		load PowerLoad
+	}{
+		{PowerNodeOutlet, PowerLoad{RatedWatts: 2080, BudgetedWatts: 500, MeasuredWatts: 350}},
+		{PowerNodeRackPDU, PowerLoad{RatedWatts: 6240, BudgetedWatts: 1200, MeasuredWatts: 1800}},
+		{PowerNodeBreaker, PowerLoad{RatedWatts: 7200, BudgetedWatts: 5000}},
+		{PowerNodePanel, PowerLoad{BudgetedWatts: 5000}},
+		{PowerNodeUPS, PowerLoad{BudgetedWatts: 90000}},
+	}
+	for i, tt := range tests {
+		node := chain.Nodes[i]
+		if node.Kind != tt.kind || node.Load != tt.load {
+			t.Errorf("Node %d: expected %s %+v, got %s %+v", i, tt.kind, tt.load, node.Kind, node.Load)
+		}
+	}
+	if pct := chain.Nodes[1].Load.MeasuredPercent(); math.Abs(pct-28.85) > 0.01 {
+		t.Errorf("Expected PDU at 28.85%% measured, got %.2f", pct)
+	}
+
+	if chains[1].Complete || chains[1].Problem != "srv-01 PS2 is not connected" {
+		t.Errorf("Expected PS2 to be reported unconnected, got %+v", chains[1])
+	}
+}
+
+func TestGetCabinetPowerChains(t *testing.T) {
+	server := newPowerServer(t)
+	defer server.Close()
+
+	client, err := New(server.URL+"/api/v2", "testuser", "testpass")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	chains, err := client.GetCabinetPowerChains(context.Background(), "1")
+	if err != nil {
+		t.Fatalf("GetCabinetPowerChains failed: %v", err)
+	}
+	if len(chains) != 1 || chains[0].Path() != "Input -> PDU-A -> PNL-1 CB1 -> PNL-1 -> UPS-A" || !chains[0].Complete {
+		t.Errorf("Expected one chain from PDU-A, got %+v", chains)
+	}
+}
+
+func TestGetPowerItems(t *testing.T) {
+	var location string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/authentication/login":
+			w.Header().Set("Authorization", "Bearer mock-token-12345")
+		case "/api/v2/quicksearch/items":
+			if r.URL.Query().Get("pageNumber") != "1" {
+				w.Write([]byte(`{"searchResults":{"items":[]}}`))
+				return
+			}
+			location = r.URL.Query().Get("location")
+			var payload quicksearchPayload
+			json.NewDecoder(r.Body).Decode(&payload)
+			payload.write(t, w, `[
+				{"id":"1","cmbCabinet":"A1","tiItemOriginalPower":"500","tiEffectivePower":"300","tiPotentialPower":"750"},
+				{"id":"2","cmbCabinet":"A1","tiItemOriginalPower":"1200","tiEffectivePower":"0"},
+				{"id":"3","cmbCabinet":"B1","tiItemOriginalPower":"800","tiEffectivePower":"650"},
+				{"id":"4","cmbCabinet":"B1"}
+			]`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	// The minimal column set lacks the cabinet and the effective and
+	// potential power columns
+	client, err := New(server.URL+"/api/v2", "testuser", "testpass", WithLimitedFields())
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		params   PowerParams
+		expected []string
+		wantErr  bool
+	}{
+		{"all powered", PowerParams{}, []string{"1", "2", "3"}, false},
+		{"cabinet", PowerParams{Cabinet: "A1"}, []string{"1", "2"}, false},
+		{"range", PowerParams{MinPower: 600, MaxPower: 1000}, []string{"3"}, false},
+		{"consumption", PowerParams{PowerType: PowerConsumption, MinPower: 400}, []string{"3"}, false},
+		{"potential", PowerParams{PowerType: "Potential"}, []string{"1"}, false},
+		{"location", PowerParams{Location: "RDU2", Cabinet: "B1"}, []string{"3"}, false},
+		{"unknown type", PowerParams{PowerType: "peak"}, nil, true},
+		{"inverted range", PowerParams{MinPower: 10, MaxPower: 5}, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, err := client.GetPowerItems(context.Background(), tt.params)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+
+			var ids []string
+			for _, item := range items {
+				ids = append(ids, item.ID)
+			}
+			if strings.Join(ids, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("Expected items %v, got %v", tt.expected, ids)
+			}
+			if !tt.wantErr && location != tt.params.Location {
+				t.Errorf("Expected location %q to be sent, got %q", tt.params.Location, location)
+			}
+		})
+	}
+}
+
+func TestPowerColumn(t *testing.T) {
+	tests := map[string]string{
+		"":               "tiItemOriginalPower",
+		PowerOriginal:    "tiItemOriginalPower",
+		PowerConsumption: "tiEffectivePower",
+		"Effective":      "tiEffectivePower",
+		PowerPotential:   "tiPotentialPower",
+		"peak":           "",
+	}
+
+	for powerType, expected := range tests {
+		if got := PowerColumn(powerType); got != expected {
+			t.Errorf("Expected column %q for power type %q, got %q", expected, powerType, got)
+		}
+		if _, err := PowerOf(DCTrackItem{}, powerType); (err == nil) != (expected != "") {
+			t.Errorf("Expected PowerOf to accept power type %q exactly when it has a column, got %v", powerType, err)
+		}
+	}
+}
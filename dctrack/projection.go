@@ -0,0 +1,210 @@
+package dctrack
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// columnTag is the struct tag naming the column a projection field is
+// decoded from, e.g. `dctrack:"cmbCabinet"`
+const columnTag = "dctrack"
+
+var (
+	timeType        = reflect.TypeOf(time.Time{})
+	customValueType = reflect.TypeOf(CustomFieldValue{})
+)
+
+// GetItemsAs retrieves the items matching params decoded into T, a struct
+// whose fields carry dctrack tags naming their columns:
+//
+//	type rackRow struct {
+//		Name    string    `dctrack:"tiName"`
+//		Cabinet string    `dctrack:"cmbCabinet"`
+//		Power   float64   `dctrack:"tiEffectivePower"`
+//		Updated time.Time `dctrack:"lastUpdatedOn"`
+//	}
+//	rows, err := dctrack.GetItemsAs[rackRow](ctx, client, dctrack.ByLocation("RDU2"))
+//
+// Only the tagged columns are requested unless params.Columns is set.
+func GetItemsAs[T any](ctx context.Context, c *Client, params ItemsParams) ([]T, error) {
+	var zero T
+	columns, err := ProjectionColumns(zero)
+	if err != nil {
+		return nil, err
+	}
+	if len(params.Columns) == 0 {
+		params.Columns = columns
+	}
+
+	payload, err := c.itemsPayload(params)
+	if err != nil {
+		return nil, fmt.Errorf("error selecting columns: %w", err)
+	}
+
+	pages, err := c.collectPages(ctx, params, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []T
+	for _, page := range pages {
+		for i, record := range page.raw {
+			var row T
+			if err := DecodeRecord(record, &row); err != nil {
+				c.logger.Warn("Error decoding DCTrack record",
+					zap.Int("record_index", i),
+					zap.Error(err))
+				continue
+			}
+			rows = append(rows, row)
+		}
+	}
+
+	c.logger.Info("Fetched projected items from DCTrack",
+		zap.String("type", reflect.TypeOf(zero).String()),
+		zap.Int("count", len(rows)))
+	return rows, nil
+}
+
+// ProjectionColumns returns the columns named by the dctrack tags of v, a
+// struct or pointer to one, in field order
+func ProjectionColumns(v interface{}) ([]string, error) {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("projection must be a struct, got %v", t)
+	}
+
+	var columns []string
+	for i := 0; i < t.NumField(); i++ {
+		if column := fieldColumn(t.Field(i)); column != "" {
+			columns = append(columns, column)
+		}
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("projection %s has no dctrack tags", t)
+	}
+	return columns, nil
+}
+
+// DecodeRecord copies the columns of a quicksearch record into the tagged
+// fields of the struct dest points to. Strings, numbers, bools, time.Time,
+// CustomFieldValue and interface{} fields are supported, as are pointers
+// to them, which stay nil when the column is missing or null.
+func DecodeRecord(record map[string]interface{}, dest interface{}) error {
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("decode destination must be a pointer to a struct, got %T", dest)
+	}
+	v = v.Elem()
+
+	for i := 0; i < v.NumField(); i++ {
+		column := fieldColumn(v.Type().Field(i))
+		if column == "" {
+			continue
+		}
+		raw, ok := record[column]
+		if !ok || raw == nil {
+			continue
+		}
+		if err := setField(v.Field(i), raw); err != nil {
+			return fmt.Errorf("error decoding column %s: %w", column, err)
+		}
+	}
+	return nil
+}
+
+// fieldColumn returns the column of an exported, tagged field, or ""
+func fieldColumn(field reflect.StructField) string {
+	if !field.IsExported() {
+		return ""
+	}
+	column, _, _ := strings.Cut(field.Tag.Get(columnTag), ",")
+	if column == "-" {
+		return ""
+	}
+	return column
+}
+
+// setField converts raw to the type of field
+func setField(field reflect.Value, raw interface{}) error {
+	if field.Kind() == reflect.Pointer {
+		value := reflect.New(field.Type().Elem())
+		if err := setField(value.Elem(), raw); err != nil {
+			return err
+		}
+		field.Set(value)
+		return nil
+	}
+
+	text := formatValue(raw)
+	switch {
+	case field.Type() == timeType:
+		if text == "" {
+			return nil
+		}
+		t, err := parseTime(text)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(t))
+		return nil
+	case field.Type() == customValueType:
+		field.Set(reflect.ValueOf(NewCustomFieldValue(raw)))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(text)
+	case reflect.Bool:
+		b, err := NewCustomFieldValue(raw).Bool()
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if text == "" {
+			return nil
+		}
+		f, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return fmt.Errorf("%q is not a number", text)
+		}
+		field.SetInt(int64(f))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if text == "" {
+			return nil
+		}
+		f, err := strconv.ParseFloat(text, 64)
+		if err != nil || f < 0 {
+			return fmt.Errorf("%q is not a non-negative number", text)
+		}
+		field.SetUint(uint64(f))
+	case reflect.Float32, reflect.Float64:
+		if text == "" {
+			return nil
+		}
+		f, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return fmt.Errorf("%q is not a number", text)
+		}
+		field.SetFloat(f)
+	case reflect.Interface:
+		if !reflect.TypeOf(raw).AssignableTo(field.Type()) {
+			return fmt.Errorf("%T is not assignable to %s", raw, field.Type())
+		}
+		field.Set(reflect.ValueOf(raw))
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+	return nil
+}
@@ -0,0 +1,221 @@
+package dctrack
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestValidateColumns(t *testing.T) {
+	tests := []struct {
+		name     string
+		columns  []string
+		expected []ErrorDetail
+	}{
+		{"standard columns", []string{"tiName", "cmbLocation", "lastServiceDate"}, nil},
+		{"custom field", []string{"tiCustomField_Rack Budget"}, nil},
+		{"wrong case", []string{"cmblocation"}, []ErrorDetail{
+			{Field: "cmblocation", Message: "is not a known column, did you mean cmbLocation?"},
+		}},
+		{"unknown and bare prefix", []string{"tiName", "tiColour", "tiCustomField_"}, []ErrorDetail{
+			{Field: "tiColour", Message: "is not a known column"},
+			{Field: "tiCustomField_", Message: "is not a known column"},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateColumns(tt.columns)
+			if tt.expected == nil {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrValidation) || !reflect.DeepEqual(FieldErrors(err), tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+type rackRow struct {
+	Name     string           `dctrack:"tiName"`
+	Cabinet  string           `dctrack:"cmbCabinet"`
+	RUs      int              `dctrack:"tiRUs"`
+	Power    *float64         `dctrack:"tiEffectivePower"`
+	Updated  time.Time        `dctrack:"lastUpdatedOn"`
+	Budget   CustomFieldValue `dctrack:"tiCustomField_Rack Budget"`
+	Derate   bool             `dctrack:"chkDerateAmps"`
+	Ignored  string           `dctrack:"-"`
+	Untagged string
+}
+
+func TestGetItemsAs(t *testing.T) {
+	var requested [][]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/authentication/login":
+			w.Header().Set("Authorization", "Bearer mock-token-12345")
+		case "/api/v2/quicksearch/items":
+			var payload struct {
+				SelectedColumns []map[string]string `json:"selectedColumns"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Errorf("Failed to decode payload: %v", err)
+			}
+			var columns []string
+			for _, column := range payload.SelectedColumns {
+				columns = append(columns, column["name"])
+			}
+			requested = append(requested, columns)
+
+			if r.URL.Query().Get("pageNumber") != "1" {
+				w.Write([]byte(`{"searchResults":{"items":[]}}`))
+				return
+			}
+			w.Write([]byte(`{"searchResults":{"items":[
+				{"id":"1","tiName":"web-01","cmbCabinet":"CAB-A1","tiRUs":"2","tiEffectivePower":350.5,
+				 "lastUpdatedOn":"2024-05-01T10:00:00Z","tiCustomField_Rack Budget":"1200","chkDerateAmps":"true"},
+				{"id":"2","tiName":"web-02","tiEffectivePower":null},
+				{"id":"3","tiName":"bad","tiRUs":"two"}
+			]}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(server.URL+"/api/v2", "testuser", "testpass")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx := context.Background()
+
+	rows, err := GetItemsAs[rackRow](ctx, client, ItemsParams{})
+	if err != nil {
+		t.Fatalf("GetItemsAs failed: %v", err)
+	}
+
+	expectedColumns := []string{"id", "tiName", "cmbCabinet", "tiRUs", "tiEffectivePower", "lastUpdatedOn",
+		"tiCustomField_Rack Budget", "chkDerateAmps"}
+	if len(requested) != 1 || !reflect.DeepEqual(requested[0], expectedColumns) {
+		t.Errorf("Expected columns %v, got %v", expectedColumns, requested)
+	}
+
+	// The record with an unparseable RU count is skipped
+	if len(rows) != 2 {
+		t.Fatalf("Expected 2 rows, got %+v", rows)
+	}
+	first := rows[0]
+	if first.Name != "web-01" || first.Cabinet != "CAB-A1" || first.RUs != 2 || !first.Derate {
+		t.Errorf("Unexpected row %+v", first)
+	}
+	if first.Power == nil || *first.Power != 350.5 {
+		t.Errorf("Expected power 350.5, got %v", first.Power)
+	}
+	if !first.Updated.Equal(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected update time, got %v", first.Updated)
+	}
+	if budget, err := first.Budget.Float(); err != nil || budget != 1200 {
+		t.Errorf("Expected budget 1200, got %v (%v)", budget, err)
+	}
+	if rows[1].Power != nil {
+		t.Errorf("Expected nil power for a null column, got %v", *rows[1].Power)
+	}
+
+	// Explicit columns select the DCTrackItem fields that are needed
+	requested = nil
+	items, err := client.GetItemsWithParams(ctx, NewFilterBuilder().WithColumns("tiName", "cmbCabinet", "tiName").Build())
+	if err != nil {
+		t.Fatalf("GetItemsWithParams failed: %v", err)
+	}
+	if !reflect.DeepEqual(requested, [][]string{{"id", "tiName", "cmbCabinet"}}) {
+		t.Errorf("Expected id, tiName and cmbCabinet, got %v", requested)
+	}
+	if len(items) != 3 || items[0].Cabinet != "CAB-A1" {
+		t.Errorf("Expected mapped items, got %+v", items)
+	}
+
+	// Unknown columns fail before any request
+	requested = nil
+	if _, err := client.GetItemsWithParams(ctx, ItemsParams{Columns: []string{"tiColour"}}); !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected ErrValidation, got %v", err)
+	}
+	for _, err := range client.Items(ctx, ItemsParams{Columns: []string{"tiColour"}}) {
+		if !errors.Is(err, ErrValidation) {
+			t.Errorf("Expected ErrValidation from Items, got %v", err)
+		}
+	}
+	if len(requested) != 0 {
+		t.Errorf("Expected no requests for unknown columns, got %v", requested)
+	}
+}
+
+func TestStrictMappingColumns(t *testing.T) {
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/authentication/login":
+			w.Header().Set("Authorization", "Bearer mock-token-12345")
+		case "/api/v2/quicksearch/items":
+			if r.URL.Query().Get("pageNumber") != "1" {
+				w.Write([]byte(`{"searchResults":{"items":[]}}`))
+				return
+			}
+			var payload quicksearchPayload
+			json.NewDecoder(r.Body).Decode(&payload)
+			requested = nil
+			for _, column := range payload.SelectedColumns {
+				requested = append(requested, column.Name)
+			}
+			payload.write(t, w, `[
+				{"id":"1","tiName":"web-01","tiClass":"Device","cmbStatus":"Installed","cmbLocation":"RDU2","cmbMake":"Dell"},
+				{"id":"2","tiName":"web-02","tiClass":"Device","cmbStatus":"Installed","cmbMake":"HPE"}
+			]`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(server.URL+"/api/v2", "testuser", "testpass", WithStrictMapping())
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	// The required columns are selected with the caller's, so only the
+	// record really missing its location is dropped
+	items, err := client.GetItemsWithParams(context.Background(), ItemsParams{Columns: []string{"cmbMake"}})
+	if err != nil {
+		t.Fatalf("GetItemsWithParams failed: %v", err)
+	}
+	expected := []string{"id", "tiName", "tiClass", "cmbStatus", "cmbLocation", "cmbMake"}
+	if !reflect.DeepEqual(requested, expected) {
+		t.Errorf("Expected columns %v, got %v", expected, requested)
+	}
+	if len(items) != 1 || items[0].ID != "1" || items[0].Make != "Dell" {
+		t.Errorf("Expected only web-01, got %+v", items)
+	}
+}
+
+func TestDecodeRecordErrors(t *testing.T) {
+	var row rackRow
+	if err := DecodeRecord(map[string]interface{}{}, row); err == nil {
+		t.Errorf("Expected error decoding into a non-pointer")
+	}
+	if err := DecodeRecord(map[string]interface{}{"chkDerateAmps": "maybe"}, &row); err == nil {
+		t.Errorf("Expected error decoding an invalid bool")
+	}
+	if _, err := ProjectionColumns(struct{ Name string }{}); err == nil {
+		t.Errorf("Expected error for a struct without tags")
+	}
+	if _, err := ProjectionColumns("tiName"); err == nil {
+		t.Errorf("Expected error for a non-struct projection")
+	}
+}
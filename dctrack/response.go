@@ -1,14 +1,32 @@
 package dctrack
 
 import (
+	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 )
 
-// DCTrackResponse represents the response structure from DCTrack API
+// DCTrackResponse represents the response structure from DCTrack API.
+// Current quicksearch responses nest items under searchResults; older
+// deployments return a flat records array.
 type DCTrackResponse struct {
-	Records []map[string]interface{} `json:"records"`
+	TotalRows     int                      `json:"totalRows"`
+	PageNumber    int                      `json:"pageNumber"`
+	PageSize      int                      `json:"pageSize"`
+	Records       []map[string]interface{} `json:"records"`
+	SearchResults struct {
+		Items []map[string]interface{} `json:"items"`
+	} `json:"searchResults"`
+}
+
+// Items returns the raw records of whichever response shape was decoded
+func (r *DCTrackResponse) Items() []map[string]interface{} {
+	if len(r.SearchResults.Items) > 0 {
+		return r.SearchResults.Items
+	}
+	return r.Records
 }
 
 // AssetEfficiency represents efficiency metrics for an asset
@@ -32,7 +50,8 @@ func (c *Client) mapDCTrackRecord(record map[string]interface{}) (DCTrackItem, e
 	// Helper function to safely get string values
 	getString := func(key string) string {
 		if val, ok := record[key]; ok && val != nil {
-			return fmt.Sprintf("%v", val)
+			// The item endpoint returns numeric IDs
+			return formatValue(val)
 		}
 		return ""
 	}
@@ -75,17 +94,8 @@ func (c *Client) mapDCTrackRecord(record map[string]interface{}) (DCTrackItem, e
 	getTime := func(key string) *time.Time {
 		if val, ok := record[key]; ok && val != nil {
 			if str := fmt.Sprintf("%v", val); str != "" && str != "null" {
-				// Try multiple time formats
-				formats := []string{
-					"2006-01-02 15:04:05-07",
-					"2006-01-02T15:04:05Z",
-					"2006-01-02",
-					time.RFC3339,
-				}
-				for _, format := range formats {
-					if t, err := time.Parse(format, str); err == nil {
-						return &t
-					}
+				if t, err := parseTime(str); err == nil {
+					return &t
 				}
 			}
 		}
@@ -101,7 +111,10 @@ func (c *Client) mapDCTrackRecord(record map[string]interface{}) (DCTrackItem, e
 	item.Location = getString("cmbLocation")
 	item.Cabinet = getString("cmbCabinet")
 	item.Rack = getString("cmbCabinet") // Often the same in DCTrack
-	item.Position = getString("cmbPosition")
+	item.Position = getString("cmbUPosition")
+	if item.Position == "" {
+		item.Position = getString("cmbPosition") // Older deployments
+	}
 	item.Height = getInt("tiRUs")
 
 	// Map basic asset information
@@ -121,6 +134,7 @@ func (c *Client) mapDCTrackRecord(record map[string]interface{}) (DCTrackItem, e
 	item.TiAssetTag = getString("tiAssetTag")
 	item.TiFormFactor = getString("tiFormFactor")
 	item.TiMounting = getString("tiMounting")
+	item.RailsUsed = getString("radioRailsUsed")
 	item.TiWidth = getFloat("tiWidth")
 	item.TiDepth = getFloat("tiDepth")
 	item.TiWeight = getFloat("tiWeight")
@@ -197,6 +211,16 @@ func (c *Client) mapDCTrackRecord(record map[string]interface{}) (DCTrackItem, e
 	item.TiCustomFieldAuditDate = getTime("tiCustomField_Audit Date")
 	item.TiCustomFieldWarrantyExpirationDate = getTime("tiCustomField_Warranty Expiration Date")
 
+	// Map every custom field the tenant returned, whatever its name
+	for key, val := range record {
+		if name, ok := strings.CutPrefix(key, customFieldPrefix); ok && val != nil {
+			if item.CustomFields == nil {
+				item.CustomFields = make(map[string]CustomFieldValue)
+			}
+			item.CustomFields[name] = NewCustomFieldValue(val)
+		}
+	}
+
 	// Map administrative data
 	item.CmbSystemAdminTeam = getString("cmbSystemAdminTeam")
 	item.CmbSystemAdmin = getString("cmbSystemAdmin")
@@ -213,5 +237,95 @@ func (c *Client) mapDCTrackRecord(record map[string]interface{}) (DCTrackItem, e
 	item.CreatedAt = time.Now()
 	item.UpdatedAt = time.Now()
 
+	if c.config.StrictMapping {
+		if err := validateRequiredFields(item); err != nil {
+			return item, err
+		}
+	}
+
 	return item, nil
 }
+
+// timeFormats are the layouts DCTrack uses for dates and timestamps
+var timeFormats = []string{
+	"2006-01-02 15:04:05-07",
+	"2006-01-02T15:04:05Z",
+	"2006-01-02",
+	time.RFC3339,
+}
+
+// parseTime parses a DCTrack date or timestamp in any of timeFormats
+func parseTime(text string) (time.Time, error) {
+	var err error
+	for _, format := range timeFormats {
+		var t time.Time
+		if t, err = time.Parse(format, text); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized time %q: %w", text, err)
+}
+
+// validateRequiredFields rejects items that downstream stores cannot key,
+// reporting the DCTrack field name that was missing
+func validateRequiredFields(item DCTrackItem) error {
+	required := []struct {
+		field string
+		value string
+	}{
+		{"id", item.ID},
+		{"tiName", item.Name},
+		{"tiClass", item.ItemClass},
+		{"cmbStatus", item.Status},
+		{"cmbLocation", item.Location},
+	}
+
+	for _, r := range required {
+		if r.value == "" {
+			return fmt.Errorf("%w: missing required field: %s", ErrMappingFailed, r.field)
+		}
+	}
+	return nil
+}
+
+// decodeRecordList accepts a bare array of records or one wrapped in the
+// first of members present in the document
+func decodeRecordList(body []byte, members ...string) ([]map[string]interface{}, error) {
+	var records []map[string]interface{}
+	if err := json.Unmarshal(body, &records); err == nil {
+		return records, nil
+	}
+
+	var wrapped map[string]json.RawMessage
+	if err := json.Unmarshal(body, &wrapped); err != nil {
+		return nil, fmt.Errorf("error decoding response: %w", err)
+	}
+	for _, member := range members {
+		if raw, ok := wrapped[member]; ok {
+			if err := json.Unmarshal(raw, &records); err != nil {
+				return nil, fmt.Errorf("error decoding %s: %w", member, err)
+			}
+			return records, nil
+		}
+	}
+	return nil, nil
+}
+
+// recordString returns the value of key in a record as text, or "" when
+// it is missing or null
+func recordString(record map[string]interface{}, key string) string {
+	if val, ok := record[key]; ok && val != nil {
+		return formatValue(val)
+	}
+	return ""
+}
+
+// recordFloat is recordString for numeric values, returning 0 when key
+// does not hold a number
+func recordFloat(record map[string]interface{}, key string) float64 {
+	f, err := strconv.ParseFloat(recordString(record, key), 64)
+	if err != nil {
+		return 0
+	}
+	return f
+}
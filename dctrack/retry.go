@@ -0,0 +1,260 @@
+package dctrack
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// RetryPolicy decides which failed requests are retried and how long to wait
+// between attempts. It applies to both login and data requests.
+type RetryPolicy struct {
+	MaxAttempts int           // Total attempts including the first
+	BaseDelay   time.Duration // Wait after the first failed attempt
+	MaxDelay    time.Duration // Cap on backoff and Retry-After waits, 0 for none
+	Multiplier  float64       // Growth of the wait per attempt, values below 1 keep it constant
+	Jitter      float64       // Fraction (0-1) by which each wait is randomly shortened or lengthened
+
+	// RetryableStatuses lists HTTP statuses worth retrying. Any other
+	// non-200 status is terminal.
+	RetryableStatuses []int
+}
+
+// DefaultRetryPolicy returns exponential backoff from 1s capped at 30s with
+// 20% jitter, retrying timeouts, rate limiting and transient server errors
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   time.Second,
+		MaxDelay:    30 * time.Second,
+		Multiplier:  2,
+		Jitter:      0.2,
+		RetryableStatuses: []int{
+			http.StatusRequestTimeout,
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// Backoff returns the wait after the given failed attempt (1-based)
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	multiplier := math.Max(p.Multiplier, 1)
+	delay := float64(p.BaseDelay) * math.Pow(multiplier, float64(attempt-1))
+	if p.Jitter > 0 {
+		delay += delay * math.Min(p.Jitter, 1) * (2*rand.Float64() - 1)
+	}
+	return p.capDelay(time.Duration(delay))
+}
+
+// RetryableStatus reports whether a response with status should be retried
+func (p RetryPolicy) RetryableStatus(status int) bool {
+	return slices.Contains(p.RetryableStatuses, status)
+}
+
+func (p RetryPolicy) capDelay(delay time.Duration) time.Duration {
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		return p.MaxDelay
+	}
+	return delay
+}
+
+// RetryAttempt describes one finished request attempt
+type RetryAttempt struct {
+	Operation  string        // Request kind, such as "login", "items", "item" or "dataports"
+	Attempt    int           // 1-based attempt number
+	StatusCode int           // 0 when no response was received
+	Err        error         // Transport error, nil when a response was received
+	Duration   time.Duration // Time spent on the attempt
+	Retry      bool          // Whether another attempt follows
+	Delay      time.Duration // Wait before the next attempt
+}
+
+// RetryHook receives every request attempt, e.g. to record metrics
+type RetryHook func(RetryAttempt)
+
+// retryPolicy returns the configured policy, or the default policy using
+// MaxRetries and RetryDelay
+func (c *Client) retryPolicy() RetryPolicy {
+	if c.config.RetryPolicy != nil {
+		policy := *c.config.RetryPolicy
+		if policy.MaxAttempts <= 0 {
+			policy.MaxAttempts = 1
+		}
+		return policy
+	}
+
+	policy := DefaultRetryPolicy()
+	if c.config.MaxRetries > 0 {
+		policy.MaxAttempts = c.config.MaxRetries
+	}
+	if c.config.RetryDelay > 0 {
+		policy.BaseDelay = c.config.RetryDelay
+	}
+	return policy
+}
+
+// do sends the request built by build, retrying transport errors and
+// retryable statuses according to the retry policy. build is called for
+// every attempt so request bodies can be replayed. Requests that are not
+// idempotent are only retried when DCTrack cannot have acted on them. The
+// final response is returned whatever its status; the caller must close
+// its body.
+func (c *Client) do(ctx context.Context, operation string, idempotent bool, build func() (*http.Request, error)) (*http.Response, error) {
+	policy := c.retryPolicy()
+
+	for attempt := 1; ; attempt++ {
+		req, err := build()
+		if err != nil {
+			return nil, err
+		}
+
+		start := time.Now()
+		resp, err := c.httpClient.Do(req)
+		info := RetryAttempt{
+			Operation: operation,
+			Attempt:   attempt,
+			Err:       err,
+			Duration:  time.Since(start),
+		}
+
+		if err != nil {
+			info.Retry = ctx.Err() == nil && isRetryableError(err)
+			info.Delay = policy.Backoff(attempt)
+		} else {
+			info.StatusCode = resp.StatusCode
+			info.Retry = policy.RetryableStatus(resp.StatusCode)
+			info.Delay = policy.Backoff(attempt)
+			if wait, ok := retryAfter(resp); ok {
+				info.Delay = policy.capDelay(wait)
+			}
+		}
+		if !idempotent && !notProcessed(resp, err) {
+			info.Retry = false
+		}
+
+		exhausted := info.Retry && attempt >= policy.MaxAttempts
+		if !info.Retry || exhausted {
+			info.Retry = false
+			info.Delay = 0
+		}
+		c.emitRetryAttempt(info)
+
+		if !info.Retry {
+			if err != nil && exhausted {
+				return nil, fmt.Errorf("all retry attempts failed, last error: %w", err)
+			}
+			return resp, err
+		}
+
+		if resp != nil {
+			// Drain so the connection can be reused
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		c.logger.Warn("Request failed, retrying",
+			zap.String("operation", operation),
+			zap.Int("attempt", attempt),
+			zap.Int("status", info.StatusCode),
+			zap.Error(err),
+			zap.Duration("retry_delay", info.Delay))
+
+		if err := sleepContext(ctx, info.Delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// emitRetryAttempt delivers an attempt to every configured hook
+func (c *Client) emitRetryAttempt(attempt RetryAttempt) {
+	for _, hook := range c.config.RetryHooks {
+		if hook != nil {
+			hook(attempt)
+		}
+	}
+}
+
+// retryAfter parses the Retry-After header of 429 and 503 responses,
+// given either in seconds or as an HTTP date
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable {
+		return 0, false
+	}
+
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0), true
+	}
+	return 0, false
+}
+
+// notProcessed reports whether a failed attempt certainly left DCTrack
+// unchanged: the connection was never made, or the server turned the
+// request away with 429, or 503 and a Retry-After
+func notProcessed(resp *http.Response, err error) bool {
+	if err != nil {
+		var opErr *net.OpError
+		return errors.As(err, &opErr) && opErr.Op == "dial"
+	}
+	if resp.StatusCode == http.StatusServiceUnavailable {
+		_, ok := retryAfter(resp)
+		return ok
+	}
+	return resp.StatusCode == http.StatusTooManyRequests
+}
+
+// isRetryableError reports whether a transport error may succeed on retry.
+// Certificate problems will not fix themselves; connection failures and
+// timeouts might.
+func isRetryableError(err error) bool {
+	var (
+		verifyErr    *tls.CertificateVerificationError
+		authorityErr x509.UnknownAuthorityError
+		hostnameErr  x509.HostnameError
+		invalidErr   x509.CertificateInvalidError
+	)
+	if errors.As(err, &verifyErr) || errors.As(err, &authorityErr) ||
+		errors.As(err, &hostnameErr) || errors.As(err, &invalidErr) {
+		return false
+	}
+	return true
+}
+
+// sleepContext waits for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,315 @@
+package dctrack
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fastRetryPolicy retries quickly and deterministically
+func fastRetryPolicy() RetryPolicy {
+	policy := DefaultRetryPolicy()
+	policy.BaseDelay = time.Millisecond
+	policy.MaxDelay = 20 * time.Millisecond
+	policy.Jitter = 0
+	return policy
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second, Multiplier: 2}
+
+	expected := []time.Duration{100, 200, 400, 800, 1000, 1000}
+	for i, want := range expected {
+		if got := policy.Backoff(i + 1); got != want*time.Millisecond {
+			t.Errorf("Attempt %d: expected %v, got %v", i+1, want*time.Millisecond, got)
+		}
+	}
+
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		got := policy.Backoff(2)
+		if got < 100*time.Millisecond || got > 300*time.Millisecond {
+			t.Fatalf("Expected jittered delay within 100ms-300ms, got %v", got)
+		}
+	}
+
+	constant := RetryPolicy{BaseDelay: time.Second}
+	if got := constant.Backoff(5); got != time.Second {
+		t.Errorf("Expected constant backoff without multiplier, got %v", got)
+	}
+}
+
+func TestRetryStatusClassification(t *testing.T) {
+	tests := []struct {
+		name             string
+		status           int
+		expectedRequests int32
+	}{
+		{"bad request is terminal", http.StatusBadRequest, 1},
+		{"forbidden is terminal", http.StatusForbidden, 1},
+		{"not found is terminal", http.StatusNotFound, 1},
+		{"server error is retried", http.StatusInternalServerError, 3},
+		{"bad gateway is retried", http.StatusBadGateway, 3},
+		{"rate limit is retried", http.StatusTooManyRequests, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/api/v2/authentication/login" {
+					w.Header().Set("Authorization", "Bearer mock-token-12345")
+					return
+				}
+				requests.Add(1)
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			client, err := New(server.URL+"/api/v2", "testuser", "testpass", WithRetryPolicy(fastRetryPolicy()))
+			if err != nil {
+				t.Fatalf("Failed to create client: %v", err)
+			}
+
+			if _, err := client.GetItems(context.Background()); err == nil {
+				t.Errorf("Expected error for status %d", tt.status)
+			}
+			if requests.Load() != tt.expectedRequests {
+				t.Errorf("Expected %d requests, got %d", tt.expectedRequests, requests.Load())
+			}
+		})
+	}
+}
+
+func TestRetryHonorsRetryAfter(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v2/authentication/login" {
+			w.Header().Set("Authorization", "Bearer mock-token-12345")
+			return
+		}
+		if requests.Add(1) == 1 {
+			w.Header().Set("Retry-After", "120")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"searchResults":{"items":[]}}`))
+	}))
+	defer server.Close()
+
+	var attempts []RetryAttempt
+	client, err := New(server.URL+"/api/v2", "testuser", "testpass",
+		WithRetryPolicy(fastRetryPolicy()),
+		WithRetryHook(func(a RetryAttempt) { attempts = append(attempts, a) }))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	if _, err := client.GetItems(context.Background()); err != nil {
+		t.Fatalf("GetItems failed: %v", err)
+	}
+
+	// Login, the 503 and the successful retry
+	if len(attempts) != 3 {
+		t.Fatalf("Expected 3 attempts, got %d: %+v", len(attempts), attempts)
+	}
+	throttled := attempts[1]
+	if throttled.Operation != "items" || throttled.StatusCode != http.StatusServiceUnavailable || !throttled.Retry {
+		t.Errorf("Unexpected throttled attempt: %+v", throttled)
+	}
+	// Retry-After of two minutes is capped by MaxDelay
+	if throttled.Delay != 20*time.Millisecond {
+		t.Errorf("Expected Retry-After capped at 20ms, got %v", throttled.Delay)
+	}
+	if final := attempts[2]; final.StatusCode != http.StatusOK || final.Retry || final.Attempt != 2 {
+		t.Errorf("Unexpected final attempt: %+v", final)
+	}
+}
+
+func TestCreateItemNotRetried(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		retryAfter    string
+		expectedPosts int32
+	}{
+		{"bad gateway", http.StatusBadGateway, "", 1},
+		{"server error", http.StatusInternalServerError, "", 1},
+		{"unavailable", http.StatusServiceUnavailable, "", 1},
+		{"unavailable with retry-after", http.StatusServiceUnavailable, "0", 2},
+		{"rate limit", http.StatusTooManyRequests, "", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var posts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/api/v2/authentication/login" {
+					w.Header().Set("Authorization", "Bearer mock-token-12345")
+					return
+				}
+				if r.Method != http.MethodPost {
+					t.Errorf("Unexpected %s %s", r.Method, r.URL.Path)
+					return
+				}
+				if posts.Add(1) == 1 {
+					if tt.retryAfter != "" {
+						w.Header().Set("Retry-After", tt.retryAfter)
+					}
+					w.WriteHeader(tt.status)
+					return
+				}
+				w.Write([]byte(`{"id":"101","tiName":"web-01"}`))
+			}))
+			defer server.Close()
+
+			client, err := New(server.URL+"/api/v2", "testuser", "testpass", WithRetryPolicy(fastRetryPolicy()))
+			if err != nil {
+				t.Fatalf("Failed to create client: %v", err)
+			}
+
+			_, err = client.CreateItem(context.Background(), ItemInput{ItemClass: Ptr("Device"), Name: Ptr("web-01"), Location: Ptr("RDU2")})
+			if posts.Load() != tt.expectedPosts {
+				t.Errorf("Expected %d POSTs, got %d", tt.expectedPosts, posts.Load())
+			}
+			if (err == nil) != (tt.expectedPosts == 2) {
+				t.Errorf("Unexpected error after %d POSTs: %v", posts.Load(), err)
+			}
+		})
+	}
+}
+
+func TestRetryAfterParsing(t *testing.T) {
+	resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+
+	resp.Header.Set("Retry-After", "7")
+	if d, ok := retryAfter(resp); !ok || d != 7*time.Second {
+		t.Errorf("Expected 7s, got %v (%t)", d, ok)
+	}
+
+	resp.Header.Set("Retry-After", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	if d, ok := retryAfter(resp); !ok || d < 59*time.Minute {
+		t.Errorf("Expected about an hour from HTTP date, got %v (%t)", d, ok)
+	}
+
+	resp.Header.Set("Retry-After", "soon")
+	if _, ok := retryAfter(resp); ok {
+		t.Errorf("Expected invalid Retry-After to be ignored")
+	}
+
+	resp.StatusCode = http.StatusInternalServerError
+	resp.Header.Set("Retry-After", "7")
+	if _, ok := retryAfter(resp); ok {
+		t.Errorf("Expected Retry-After to be ignored for 500")
+	}
+}
+
+func TestRetrySleepRespectsContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v2/authentication/login" {
+			w.Header().Set("Authorization", "Bearer mock-token-12345")
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	policy := DefaultRetryPolicy()
+	policy.BaseDelay = time.Minute
+	policy.MaxDelay = time.Minute
+	client, err := New(server.URL+"/api/v2", "testuser", "testpass", WithRetryPolicy(policy))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err = client.GetItems(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected retry wait to stop with the context, took %v", elapsed)
+	}
+}
+
+func TestLoginRetries(t *testing.T) {
+	var logins atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v2/authentication/login" {
+			if logins.Add(1) < 3 {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			w.Header().Set("Authorization", "Bearer mock-token-12345")
+			return
+		}
+		w.Write([]byte(`{"searchResults":{"items":[]}}`))
+	}))
+	defer server.Close()
+
+	client, err := New(server.URL+"/api/v2", "testuser", "testpass", WithRetryPolicy(fastRetryPolicy()))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	if _, err := client.GetItems(context.Background()); err != nil {
+		t.Fatalf("Expected login to succeed on the third attempt: %v", err)
+	}
+	if logins.Load() != 3 {
+		t.Errorf("Expected 3 login attempts, got %d", logins.Load())
+	}
+}
+
+func TestLoginRejectedCredentialsNotRetried(t *testing.T) {
+	var logins atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logins.Add(1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client, err := New(server.URL+"/api/v2", "testuser", "wrong", WithRetryPolicy(fastRetryPolicy()))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	if _, err := client.GetItems(context.Background()); err == nil {
+		t.Errorf("Expected login failure")
+	}
+	if logins.Load() != 1 {
+		t.Errorf("Expected a single login attempt, got %d", logins.Load())
+	}
+}
+
+func TestRetryNetworkErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := server.URL
+	server.Close() // Connections are refused from here on
+
+	var attempts atomic.Int32
+	client, err := New(url+"/api/v2", "testuser", "testpass",
+		WithRetryPolicy(fastRetryPolicy()),
+		WithRetryHook(func(a RetryAttempt) {
+			attempts.Add(1)
+			if a.Err == nil || a.StatusCode != 0 {
+				t.Errorf("Expected a transport error without status, got %+v", a)
+			}
+		}))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	if _, err := client.GetItems(context.Background()); err == nil {
+		t.Errorf("Expected connection error")
+	}
+	if attempts.Load() != 3 {
+		t.Errorf("Expected 3 attempts, got %d", attempts.Load())
+	}
+}
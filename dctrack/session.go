@@ -0,0 +1,139 @@
+package dctrack
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// SessionEventType identifies a change in the client's authentication session
+type SessionEventType string
+
+const (
+	// SessionLogin is emitted after a login that was not triggered by an expiring token
+	SessionLogin SessionEventType = "login"
+	// SessionRefresh is emitted after a token was replaced proactively before it lapsed
+	SessionRefresh SessionEventType = "refresh"
+	// SessionExpired is emitted when a cached token is found to be past its expiry
+	SessionExpired SessionEventType = "expired"
+	// SessionRejected is emitted when DCTrack answers 401 for a token the client still held
+	SessionRejected SessionEventType = "rejected"
+)
+
+// SessionEvent describes a session change delivered to SessionHook callbacks
+type SessionEvent struct {
+	Type      SessionEventType
+	ExpiresAt time.Time // Zero when the token carries no exp claim
+	Err       error     // Set when the login that followed the event failed
+}
+
+// SessionHook observes login, refresh and expiry events
+type SessionHook func(SessionEvent)
+
+// TokenExpiry decodes the exp claim from a DCTrack JWT, reporting false when there is none
+func TokenExpiry(token string) (time.Time, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, false
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	var claims struct {
+		Exp json.Number `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == "" {
+		return time.Time{}, false
+	}
+
+	exp, err := claims.Exp.Float64()
+	if err != nil || exp <= 0 {
+		return time.Time{}, false
+	}
+
+	return time.Unix(int64(exp), 0), true
+}
+
+// ensureToken returns a usable JWT, logging in when none is cached and
+// refreshing it once it is within TokenRefreshMargin of its expiry.
+// Concurrent callers wait for a single login instead of racing. Session
+// hooks run after authMu is released, so they may call the client.
+func (c *Client) ensureToken(ctx context.Context) (string, error) {
+	token, events, err := c.refreshToken(ctx)
+	c.emitSessionEvents(events)
+	return token, err
+}
+
+// refreshToken does the work of ensureToken under authMu, returning the
+// session events to deliver once it is released
+func (c *Client) refreshToken(ctx context.Context) (string, []SessionEvent, error) {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+
+	now := time.Now()
+	if c.token != "" {
+		if c.tokenExpiry.IsZero() || now.Add(c.config.TokenRefreshMargin).Before(c.tokenExpiry) {
+			return c.token, nil, nil
+		}
+	}
+
+	var events []SessionEvent
+	eventType := SessionLogin
+	if c.token != "" {
+		if !now.Before(c.tokenExpiry) {
+			events = append(events, SessionEvent{Type: SessionExpired, ExpiresAt: c.tokenExpiry})
+		} else {
+			eventType = SessionRefresh
+		}
+	}
+
+	if err := c.login(ctx); err != nil {
+		c.token = ""
+		c.tokenExpiry = time.Time{}
+		return "", append(events, SessionEvent{Type: eventType, Err: err}), err
+	}
+
+	return c.token, append(events, SessionEvent{Type: eventType, ExpiresAt: c.tokenExpiry}), nil
+}
+
+// invalidateToken drops the cached token after DCTrack rejected it, unless
+// another goroutine has already replaced it
+func (c *Client) invalidateToken(stale string) {
+	c.authMu.Lock()
+	if c.token == "" || c.token != stale {
+		c.authMu.Unlock()
+		return
+	}
+
+	c.logger.Debug("DCTrack rejected cached token, re-authenticating")
+	event := SessionEvent{Type: SessionRejected, ExpiresAt: c.tokenExpiry}
+	c.token = ""
+	c.tokenExpiry = time.Time{}
+	c.authMu.Unlock()
+
+	c.emitSessionEvents([]SessionEvent{event})
+}
+
+// emitSessionEvents delivers events, in order, to every configured hook.
+// It must not be called with authMu held.
+func (c *Client) emitSessionEvents(events []SessionEvent) {
+	for _, event := range events {
+		c.logger.Debug("DCTrack session event",
+			zap.String("type", string(event.Type)),
+			zap.Time("expires_at", event.ExpiresAt),
+			zap.Error(event.Err))
+
+		for _, hook := range c.config.SessionHooks {
+			if hook != nil {
+				hook(event)
+			}
+		}
+	}
+}
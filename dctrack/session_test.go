@@ -0,0 +1,267 @@
+package dctrack
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testJWT builds an unsigned JWT carrying the given expiry
+func testJWT(id int, exp time.Time) string {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`))
+	payload := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"sub":"token-%d","exp":%d}`, id, exp.Unix())))
+	return header + "." + payload + ".sig"
+}
+
+// newSessionTestServer returns a mock DCTrack server that issues tokens from
+// issue and serves a single page of items to requests bearing a current token
+func newSessionTestServer(t *testing.T, logins *int32, issue func(n int) string, valid func(token string) bool) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/authentication/login":
+			n := atomic.AddInt32(logins, 1)
+			w.Header().Set("Authorization", "Bearer "+issue(int(n)))
+			w.WriteHeader(http.StatusOK)
+		case "/api/v2/quicksearch/items":
+			auth := r.Header.Get("Authorization")
+			if len(auth) < 8 || !valid(auth[7:]) {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"records":[{"id":"item-1","tiName":"Server 1"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestTokenExpiry(t *testing.T) {
+	exp := time.Unix(1900000000, 0)
+
+	got, ok := TokenExpiry(testJWT(1, exp))
+	if !ok {
+		t.Fatalf("Expected expiry to be decoded")
+	}
+	if !got.Equal(exp) {
+		t.Errorf("Expected expiry %v, got %v", exp, got)
+	}
+
+	if _, ok := TokenExpiry("opaque-token"); ok {
+		t.Errorf("Expected no expiry for opaque token")
+	}
+}
+
+func TestSessionReusesToken(t *testing.T) {
+	var logins int32
+	server := newSessionTestServer(t, &logins,
+		func(n int) string { return testJWT(n, time.Now().Add(time.Hour)) },
+		func(string) bool { return true })
+	defer server.Close()
+
+	client, err := New(server.URL+"/api/v2", "testuser", "testpass")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+	for i := 0; i < 5; i++ {
+		if _, err := client.GetItems(ctx); err != nil {
+			t.Fatalf("GetItems failed: %v", err)
+		}
+	}
+
+	if logins != 1 {
+		t.Errorf("Expected 1 login, got %d", logins)
+	}
+}
+
+func TestSessionRefreshesBeforeExpiry(t *testing.T) {
+	var logins int32
+	server := newSessionTestServer(t, &logins,
+		func(n int) string {
+			// First token is already inside the refresh margin
+			if n == 1 {
+				return testJWT(n, time.Now().Add(30*time.Second))
+			}
+			return testJWT(n, time.Now().Add(time.Hour))
+		},
+		func(string) bool { return true })
+	defer server.Close()
+
+	var events []SessionEventType
+	client, err := New(server.URL+"/api/v2", "testuser", "testpass",
+		WithTokenRefreshMargin(time.Minute),
+		WithSessionHook(func(e SessionEvent) { events = append(events, e.Type) }))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+	if _, err := client.GetItems(ctx); err != nil {
+		t.Fatalf("GetItems failed: %v", err)
+	}
+
+	if logins != 2 {
+		t.Errorf("Expected 2 logins, got %d", logins)
+	}
+
+	expected := []SessionEventType{SessionLogin, SessionRefresh}
+	if len(events) != len(expected) {
+		t.Fatalf("Expected events %v, got %v", expected, events)
+	}
+	for i := range expected {
+		if events[i] != expected[i] {
+			t.Errorf("Expected event %d to be %s, got %s", i, expected[i], events[i])
+		}
+	}
+}
+
+func TestSessionExpiredToken(t *testing.T) {
+	var logins int32
+	server := newSessionTestServer(t, &logins,
+		func(n int) string { return testJWT(n, time.Now().Add(time.Hour)) },
+		func(string) bool { return true })
+	defer server.Close()
+
+	var events []SessionEventType
+	client, err := New(server.URL+"/api/v2", "testuser", "testpass",
+		WithSessionHook(func(e SessionEvent) { events = append(events, e.Type) }))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	// Simulate a cached token that lapsed while the client was idle
+	client.token = testJWT(0, time.Now().Add(-time.Minute))
+	client.tokenExpiry = time.Now().Add(-time.Minute)
+
+	if _, err := client.GetItems(context.Background()); err != nil {
+		t.Fatalf("GetItems failed: %v", err)
+	}
+
+	if logins != 1 {
+		t.Errorf("Expected 1 login, got %d", logins)
+	}
+	if len(events) != 2 || events[0] != SessionExpired || events[1] != SessionLogin {
+		t.Errorf("Expected [expired login] events, got %v", events)
+	}
+}
+
+func TestSessionReauthenticatesOn401(t *testing.T) {
+	var logins int32
+	server := newSessionTestServer(t, &logins,
+		func(n int) string { return fmt.Sprintf("opaque-token-%d", n) },
+		// The server revokes the first token after login
+		func(token string) bool { return token != "opaque-token-1" })
+	defer server.Close()
+
+	var rejected int32
+	client, err := New(server.URL+"/api/v2", "testuser", "testpass",
+		WithMaxRetries(1),
+		WithSessionHook(func(e SessionEvent) {
+			if e.Type == SessionRejected {
+				atomic.AddInt32(&rejected, 1)
+			}
+		}))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	items, err := client.GetItems(context.Background())
+	if err != nil {
+		t.Fatalf("GetItems failed: %v", err)
+	}
+	if len(items) != 1 {
+		t.Errorf("Expected 1 item, got %d", len(items))
+	}
+	if logins != 2 {
+		t.Errorf("Expected 2 logins, got %d", logins)
+	}
+	if rejected != 1 {
+		t.Errorf("Expected 1 rejected event, got %d", rejected)
+	}
+}
+
+func TestSessionConcurrentCallers(t *testing.T) {
+	var logins int32
+	server := newSessionTestServer(t, &logins,
+		func(n int) string { return testJWT(n, time.Now().Add(time.Hour)) },
+		func(string) bool { return true })
+	defer server.Close()
+
+	client, err := New(server.URL+"/api/v2", "testuser", "testpass")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.GetItems(context.Background()); err != nil {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("GetItems failed: %v", err)
+	}
+	if logins != 1 {
+		t.Errorf("Expected 1 login shared by all goroutines, got %d", logins)
+	}
+}
+
+func TestSessionHookMayCallClient(t *testing.T) {
+	var logins int32
+	server := newSessionTestServer(t, &logins,
+		func(n int) string { return testJWT(n, time.Now().Add(time.Hour)) },
+		func(string) bool { return true })
+	defer server.Close()
+
+	var client *Client
+	hookErrs := make(chan error, 1)
+	client, err := New(server.URL+"/api/v2", "testuser", "testpass",
+		WithSessionHook(func(e SessionEvent) {
+			if e.Type == SessionLogin {
+				_, err := client.GetItems(context.Background())
+				hookErrs <- err
+			}
+		}))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.GetItems(context.Background())
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("GetItems failed: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("GetItems deadlocked on a hook calling the client")
+	}
+	if err := <-hookErrs; err != nil {
+		t.Errorf("Expected the hook's call to succeed, got %v", err)
+	}
+	if logins != 1 {
+		t.Errorf("Expected 1 login, got %d", logins)
+	}
+}
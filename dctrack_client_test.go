@@ -214,14 +214,14 @@ func TestClientIntegration(t *testing.T) {
 				"records": [
 					{
 						"id": "test-001",
-						"name": "Test Server 1",
-						"location": "TEST-DC",
-						"status": "Installed",
-						"item_class": "Device",
-						"make": "Dell",
-						"model": "PowerEdge R730",
-						"original_power": "500",
-						"last_updated_at": "2024-01-01 12:00:00-05"
+						"tiName": "Test Server 1",
+						"cmbLocation": "TEST-DC",
+						"cmbStatus": "Installed",
+						"tiClass": "Device",
+						"cmbMake": "Dell",
+						"cmbModel": "PowerEdge R730",
+						"tiItemOriginalPower": "500",
+						"lastUpdatedOn": "2024-01-01 12:00:00-05"
 					}
 				]
 			}`
@@ -272,18 +272,57 @@ func TestClientIntegration(t *testing.T) {
 	}
 }
 
+func TestClientReusesSession(t *testing.T) {
+	logins := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/authentication/login":
+			logins++
+			w.Header().Set("Authorization", "Bearer mock-token-12345")
+			w.WriteHeader(http.StatusOK)
+		case "/api/v2/quicksearch/items":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"totalRows":0,"searchResults":{"items":[]}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	var events []SessionEventType
+	client, err := NewClient(Config{
+		URL:          server.URL + "/api/v2",
+		Username:     "testuser",
+		Password:     "testpass",
+		SessionHooks: []SessionHook{func(e SessionEvent) { events = append(events, e.Type) }},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := client.GetItems(context.Background()); err != nil {
+			t.Fatalf("GetItems failed: %v", err)
+		}
+	}
+
+	if logins != 1 {
+		t.Errorf("Expected 1 login, got %d", logins)
+	}
+	if len(events) != 1 || events[0] != SessionLogin {
+		t.Errorf("Expected a single login event, got %v", events)
+	}
+}
+
 func TestSearchItems(t *testing.T) {
 	// Test search validation
-	client := &Client{
-		config: Config{
-			URL:      "https://test.com/api/v2",
-			Username: "test",
-			Password: "test",
-		},
+	client, err := New("https://test.com/api/v2", "test", "test")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
 	}
 
 	// Test empty search query
-	_, err := client.SearchItems(context.Background(), "")
+	_, err = client.SearchItems(context.Background(), "")
 	if err == nil {
 		t.Errorf("Expected error for empty search query")
 	}
@@ -0,0 +1,42 @@
+package dctrack
+
+import (
+	dct "github.com/Jethzabell/go-dctrack-client/dctrack"
+)
+
+// Credential types are shared with the dctrack subpackage so providers work with either client
+type (
+	Credentials            = dct.Credentials
+	CredentialProvider     = dct.CredentialProvider
+	CredentialProviderFunc = dct.CredentialProviderFunc
+	StaticProvider         = dct.StaticProvider
+	PasswordFileProvider   = dct.PasswordFileProvider
+	EnvProvider            = dct.EnvProvider
+	CommandProvider        = dct.CommandProvider
+	VaultProvider          = dct.VaultProvider
+)
+
+// NewStaticProvider creates a provider for fixed credentials
+func NewStaticProvider(username, password string) *StaticProvider {
+	return dct.NewStaticProvider(username, password)
+}
+
+// NewPasswordFileProvider creates a provider reading the password from path
+func NewPasswordFileProvider(username, path string) *PasswordFileProvider {
+	return dct.NewPasswordFileProvider(username, path)
+}
+
+// NewEnvProvider creates a provider reading DCTRACK_USERNAME and DCTRACK_PASSWORD
+func NewEnvProvider() *EnvProvider {
+	return dct.NewEnvProvider()
+}
+
+// NewCommandProvider creates a provider running command with args
+func NewCommandProvider(command string, args ...string) *CommandProvider {
+	return dct.NewCommandProvider(command, args...)
+}
+
+// NewVaultProvider creates a provider reading the secret at path
+func NewVaultProvider(address, token, path string) *VaultProvider {
+	return dct.NewVaultProvider(address, token, path)
+}
@@ -0,0 +1,15 @@
+package dctrack
+
+import (
+	dct "github.com/Jethzabell/go-dctrack-client/dctrack"
+)
+
+// ConfigError reports an invalid configuration value and names the key at fault
+type ConfigError = dct.ConfigError
+
+// LoadConfig reads the named profile from a multi-environment YAML or JSON
+// file such as config.yaml, applying defaults, "extends" inheritance and
+// DCTRACK_* environment overrides
+func LoadConfig(path, profile string) (Config, error) {
+	return dct.LoadConfig(path, profile)
+}
@@ -0,0 +1,20 @@
+package dctrack
+
+import (
+	dct "github.com/Jethzabell/go-dctrack-client/dctrack"
+)
+
+// Session types are shared with the dctrack subpackage so hooks work with either client
+type (
+	SessionEventType = dct.SessionEventType
+	SessionEvent     = dct.SessionEvent
+	SessionHook      = dct.SessionHook
+)
+
+// Session event types, see the dctrack subpackage for details
+const (
+	SessionLogin    = dct.SessionLogin
+	SessionRefresh  = dct.SessionRefresh
+	SessionExpired  = dct.SessionExpired
+	SessionRejected = dct.SessionRejected
+)
@@ -2,6 +2,9 @@ module github.com/Jethzabell/go-dctrack-client
 
 go 1.24.5
 
-require go.uber.org/zap v1.27.0
+require (
+	go.uber.org/zap v1.27.0
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require go.uber.org/multierr v1.10.0 // indirect